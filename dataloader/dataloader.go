@@ -0,0 +1,242 @@
+// Package dataloader is the runtime used by the DataLoaders generated with the
+// `loaders=go` parameter. A Loader coalesces individual loads that happen
+// within a short window into a single call to a batch function, and caches
+// the result of each key for the lifetime of the Loader.
+//
+// Loaders are intended to be created per request, so that cached values do
+// not leak between requests.
+package dataloader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWait is the default duration a Loader waits for additional
+	// keys before dispatching a batch.
+	DefaultWait = time.Millisecond
+	// DefaultMaxBatch is the default maximum number of keys in a batch.
+	// Zero means that batches are unbounded.
+	DefaultMaxBatch = 0
+)
+
+// BatchFunc loads the values for the provided keys. The returned results
+// must have the same length and order as keys.
+type BatchFunc func(ctx context.Context, keys []interface{}) []*Result
+
+// Result is the outcome of loading a single key.
+type Result struct {
+	Value interface{}
+	Error error
+}
+
+// Option configures a Loader.
+type Option func(*Loader)
+
+// WithWait sets the duration a Loader waits for additional keys before
+// dispatching a batch.
+func WithWait(wait time.Duration) Option {
+	return func(l *Loader) {
+		l.wait = wait
+	}
+}
+
+// WithMaxBatch sets the maximum number of keys in a batch. A batch is
+// dispatched immediately once it reaches this size.
+func WithMaxBatch(maxBatch int) Option {
+	return func(l *Loader) {
+		l.maxBatch = maxBatch
+	}
+}
+
+// Loader batches and caches loads of values by key. Keys must be comparable.
+type Loader struct {
+	batchFn  BatchFunc
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[interface{}]*entry
+	batch *batch
+}
+
+type entry struct {
+	done   chan struct{}
+	result *Result
+}
+
+type batch struct {
+	ctx     context.Context
+	keys    []interface{}
+	entries []*entry
+	closing bool
+}
+
+// New creates a Loader that loads keys using batchFn.
+func New(batchFn BatchFunc, opts ...Option) *Loader {
+	l := &Loader{
+		batchFn:  batchFn,
+		wait:     DefaultWait,
+		maxBatch: DefaultMaxBatch,
+		cache:    make(map[interface{}]*entry),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Load returns the value for key, waiting for the batch containing key to be
+// dispatched if the value is not already cached.
+func (l *Loader) Load(ctx context.Context, key interface{}) (interface{}, error) {
+	e := l.enqueue(ctx, key)
+	select {
+	case <-e.done:
+		return e.result.Value, e.result.Error
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// LoadMany returns the values for keys. The returned values and errors have
+// the same length and order as keys.
+func (l *Loader) LoadMany(ctx context.Context, keys []interface{}) ([]interface{}, []error) {
+	entries := make([]*entry, len(keys))
+	for i, key := range keys {
+		entries[i] = l.enqueue(ctx, key)
+	}
+
+	values := make([]interface{}, len(keys))
+	errs := make([]error, len(keys))
+	for i, e := range entries {
+		select {
+		case <-e.done:
+			values[i], errs[i] = e.result.Value, e.result.Error
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	return values, errs
+}
+
+// Prime adds a value to the cache for key, if key is not already cached.
+func (l *Loader) Prime(key interface{}, value interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.cache[key]; ok {
+		return
+	}
+	e := &entry{done: make(chan struct{}), result: &Result{Value: value}}
+	close(e.done)
+	l.cache[key] = e
+}
+
+// Clear removes key from the cache, so that the next load of key is
+// dispatched in a new batch.
+func (l *Loader) Clear(key interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.cache, key)
+}
+
+func (l *Loader) enqueue(ctx context.Context, key interface{}) *entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.cache[key]; ok {
+		return e
+	}
+
+	e := &entry{done: make(chan struct{})}
+	l.cache[key] = e
+
+	if l.batch == nil {
+		l.batch = &batch{ctx: ctx}
+		go l.dispatchAfterWait(l.batch)
+	}
+	b := l.batch
+	b.keys = append(b.keys, key)
+	b.entries = append(b.entries, e)
+
+	if l.maxBatch > 0 && len(b.keys) >= l.maxBatch {
+		l.batch = nil
+		b.closing = true
+		go l.dispatch(b)
+	}
+	return e
+}
+
+func (l *Loader) dispatchAfterWait(b *batch) {
+	time.Sleep(l.wait)
+
+	l.mu.Lock()
+	if b.closing {
+		// The batch was already dispatched because it reached maxBatch.
+		l.mu.Unlock()
+		return
+	}
+	if l.batch == b {
+		l.batch = nil
+	}
+	b.closing = true
+	l.mu.Unlock()
+
+	l.dispatch(b)
+}
+
+func (l *Loader) dispatch(b *batch) {
+	results := l.batchFn(b.ctx, b.keys)
+	for i, e := range b.entries {
+		if i < len(results) && results[i] != nil {
+			e.result = results[i]
+		} else {
+			e.result = &Result{Error: &MissingResultError{Key: b.keys[i]}}
+		}
+		close(e.done)
+	}
+}
+
+// ErrorResults returns n results that all have the provided error. It is
+// useful for batch functions where the whole batch failed.
+func ErrorResults(n int, err error) []*Result {
+	results := make([]*Result, n)
+	for i := range results {
+		results[i] = &Result{Error: err}
+	}
+	return results
+}
+
+// ForEach calls fn concurrently for each key and collects the results in
+// the same order as keys. It is useful for batch functions backed by a method
+// that loads a single key at a time.
+func ForEach(keys []interface{}, fn func(key interface{}) (interface{}, error)) []*Result {
+	results := make([]*Result, len(keys))
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key interface{}) {
+			defer wg.Done()
+			value, err := fn(key)
+			results[i] = &Result{Value: value, Error: err}
+		}(i, key)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// MissingResultError is returned for keys that the batch function did not
+// return a result for.
+type MissingResultError struct {
+	Key interface{}
+}
+
+func (e *MissingResultError) Error() string {
+	return fmt.Sprintf("dataloader: batch function returned no result for key %v", e.Key)
+}
@@ -0,0 +1,107 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type recordingBatchFunc struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+}
+
+func (r *recordingBatchFunc) load(ctx context.Context, keys []interface{}) []*Result {
+	r.mu.Lock()
+	r.batches = append(r.batches, keys)
+	r.mu.Unlock()
+
+	results := make([]*Result, len(keys))
+	for i, key := range keys {
+		results[i] = &Result{Value: key.(int) * 10}
+	}
+	return results
+}
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	r := &recordingBatchFunc{}
+	l := New(r.load)
+
+	var wg sync.WaitGroup
+	values := make([]interface{}, 3)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := l.Load(context.Background(), i+1)
+			if err != nil {
+				t.Error(err)
+			}
+			values[i] = value
+		}(i)
+	}
+	wg.Wait()
+
+	for i, value := range values {
+		if value != (i+1)*10 {
+			t.Errorf("got %v; want %d", value, (i+1)*10)
+		}
+	}
+	if len(r.batches) != 1 {
+		t.Errorf("got %d batches; want 1", len(r.batches))
+	}
+}
+
+func TestLoaderCachesKeys(t *testing.T) {
+	r := &recordingBatchFunc{}
+	l := New(r.load)
+
+	_, _ = l.Load(context.Background(), 1)
+	_, _ = l.Load(context.Background(), 1)
+	if len(r.batches) != 1 {
+		t.Errorf("got %d batches; want 1", len(r.batches))
+	}
+
+	l.Clear(1)
+	_, _ = l.Load(context.Background(), 1)
+	if len(r.batches) != 2 {
+		t.Errorf("got %d batches; want 2", len(r.batches))
+	}
+
+	l.Prime(2, 99)
+	value, _ := l.Load(context.Background(), 2)
+	if value != 99 || len(r.batches) != 2 {
+		t.Errorf("got %v after %d batches; want primed value 99 after 2 batches", value, len(r.batches))
+	}
+}
+
+func TestLoaderMaxBatch(t *testing.T) {
+	r := &recordingBatchFunc{}
+	l := New(r.load, WithMaxBatch(2))
+
+	values, errs := l.LoadMany(context.Background(), []interface{}{1, 2, 3, 4, 5})
+	for i, value := range values {
+		if errs[i] != nil {
+			t.Error(errs[i])
+		}
+		if value != (i+1)*10 {
+			t.Errorf("got %v; want %d", value, (i+1)*10)
+		}
+	}
+	if len(r.batches) != 3 {
+		t.Errorf("got %d batches; want 3", len(r.batches))
+	}
+}
+
+func TestLoaderMissingResults(t *testing.T) {
+	l := New(func(ctx context.Context, keys []interface{}) []*Result {
+		return nil
+	})
+
+	_, err := l.Load(context.Background(), 1)
+	var missing *MissingResultError
+	if !errors.As(err, &missing) || missing.Key != 1 {
+		t.Errorf("got %v; want MissingResultError for key 1", err)
+	}
+}
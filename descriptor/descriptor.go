@@ -6,16 +6,24 @@ import (
 
 	"google.golang.org/protobuf/types/descriptorpb"
 
+	"github.com/martinxsliu/protoc-gen-graphql/diagnostics"
 	graphqlpb "github.com/martinxsliu/protoc-gen-graphql/protobuf/graphql"
 )
 
 type File struct {
 	Proto   *descriptorpb.FileDescriptorProto
 	Options *graphqlpb.FileOptions
+	// Edition of the file. Files using the proto2 and proto3 syntaxes have
+	// the EDITION_PROTO2 and EDITION_PROTO3 editions respectively.
+	Edition descriptorpb.Edition
 	// All the protobuf types defined in this file, including nested types.
 	Messages []*Message
 	Enums    []*Enum
 	Services []*Service
+
+	// Source code info of the file's definitions, keyed by their path.
+	locations   map[string]*descriptorpb.SourceCodeInfo_Location
+	diagnostics *diagnostics.Collector
 }
 
 // Message represents a protobuf message.
@@ -38,6 +46,7 @@ type Message struct {
 	// Fully qualified name starting with a '.' including the package name.
 	FullName string
 	Comments string
+	Location diagnostics.Location
 }
 
 // Field represents a protobuf field.
@@ -49,8 +58,13 @@ type Field struct {
 	Parent     *Message
 	IsOneof    bool
 	OneofIndex int32
+	// Resolved presence of the field. Fields with EXPLICIT presence
+	// distinguish between unset and default values, and LEGACY_REQUIRED
+	// fields are always set.
+	Presence   descriptorpb.FeatureSet_FieldPresence
 	ForeignKey *ForeignKey
 	Comments   string
+	Location   diagnostics.Location
 }
 
 type ForeignKey struct {
@@ -59,10 +73,13 @@ type ForeignKey struct {
 	FieldName string
 }
 
+// Oneof represents a protobuf oneof. Synthetic oneofs generated for proto3
+// optional fields are not represented as a Oneof.
 type Oneof struct {
-	Proto  *descriptorpb.OneofDescriptorProto
-	Parent *Message
-	Fields []*Field
+	Proto    *descriptorpb.OneofDescriptorProto
+	Parent   *Message
+	Fields   []*Field
+	Location diagnostics.Location
 }
 
 type Enum struct {
@@ -77,12 +94,14 @@ type Enum struct {
 	// Fully qualified name starting with a '.' including the package name.
 	FullName string
 	Comments string
+	Location diagnostics.Location
 }
 
 type EnumValue struct {
 	Proto    *descriptorpb.EnumValueDescriptorProto
 	Options  *graphqlpb.EnumValueOptions
 	Comments string
+	Location diagnostics.Location
 }
 
 type Service struct {
@@ -95,14 +114,19 @@ type Service struct {
 	FullName string
 	Methods  []*Method
 	Comments string
+	Location diagnostics.Location
 }
 
 type Method struct {
-	Proto    *descriptorpb.MethodDescriptorProto
-	Options  *graphqlpb.MethodOptions
-	Service  *Service
-	Loaders  []*Loader
-	Comments string
+	Proto   *descriptorpb.MethodDescriptorProto
+	Options *graphqlpb.MethodOptions
+	Service *Service
+	Loaders []*Loader
+	// HTTP method of the method's google.api.http rule, e.g. "GET", or empty
+	// if it has none.
+	HTTPMethod string
+	Comments   string
+	Location   diagnostics.Location
 }
 
 type Loader struct {
@@ -115,27 +139,51 @@ type Loader struct {
 	Method             *Method
 }
 
-func WrapFile(proto *descriptorpb.FileDescriptorProto) *File {
+// Field numbers of the descriptor protos, which make up the paths of
+// definitions in a file's source code info.
+const (
+	fileMessagePath   = 4
+	fileEnumPath      = 5
+	fileServicePath   = 6
+	messageFieldPath  = 2
+	messageNestedPath = 3
+	messageEnumPath   = 4
+	messageOneofPath  = 8
+	enumValuePath     = 2
+	serviceMethodPath = 2
+)
+
+// WrapFile wraps a file descriptor. Problems with the options of the file's
+// definitions are recorded in diags.
+func WrapFile(proto *descriptorpb.FileDescriptorProto, diags *diagnostics.Collector) *File {
 	file := &File{
-		Proto:   proto,
-		Options: getFileOptions(proto),
+		Proto:       proto,
+		Options:     getFileOptions(proto),
+		Edition:     fileEdition(proto),
+		locations:   make(map[string]*descriptorpb.SourceCodeInfo_Location),
+		diagnostics: diags,
+	}
+	for _, location := range proto.GetSourceCodeInfo().GetLocation() {
+		key := pathKey(location.GetPath())
+		if _, ok := file.locations[key]; !ok {
+			file.locations[key] = location
+		}
 	}
 
-	for _, serviceProto := range file.Proto.GetService() {
-		wrapService(file, serviceProto)
+	for i, serviceProto := range file.Proto.GetService() {
+		wrapService(file, serviceProto, childPath(nil, fileServicePath, i))
 	}
-	for _, msgProto := range file.Proto.GetMessageType() {
-		wrapMessage(file, msgProto, nil)
+	for i, msgProto := range file.Proto.GetMessageType() {
+		wrapMessage(file, msgProto, nil, childPath(nil, fileMessagePath, i))
 	}
-	for _, enumProto := range file.Proto.GetEnumType() {
-		wrapEnum(file, enumProto, nil)
+	for i, enumProto := range file.Proto.GetEnumType() {
+		wrapEnum(file, enumProto, nil, childPath(nil, fileEnumPath, i))
 	}
-	setComments(file)
 
 	return file
 }
 
-func wrapService(file *File, proto *descriptorpb.ServiceDescriptorProto) {
+func wrapService(file *File, proto *descriptorpb.ServiceDescriptorProto, path []int32) {
 	service := &Service{
 		Proto:    proto,
 		Options:  getServiceOptions(proto),
@@ -143,19 +191,25 @@ func wrapService(file *File, proto *descriptorpb.ServiceDescriptorProto) {
 		File:     file,
 		TypeName: []string{proto.GetName()},
 		FullName: fmt.Sprintf(".%s.%s", file.Proto.GetPackage(), proto.GetName()),
+		Comments: file.comments(path),
+		Location: file.location(path),
 	}
-	wrapMethods(service)
+	wrapMethods(service, path)
 	file.Services = append(file.Services, service)
 }
 
-func wrapMethods(service *Service) {
-	for _, proto := range service.Proto.GetMethod() {
+func wrapMethods(service *Service, servicePath []int32) {
+	for i, proto := range service.Proto.GetMethod() {
+		path := childPath(servicePath, serviceMethodPath, i)
 		options := getMethodOptions(proto)
 		method := &Method{
-			Proto:   proto,
-			Options: options,
-			Service: service,
+			Proto:    proto,
+			Options:  options,
+			Service:  service,
+			Comments: service.File.comments(path),
+			Location: service.File.location(path),
 		}
+		method.HTTPMethod = getHTTPMethodOption(method)
 		if loader := getLoaderOption(method, options.GetLoadOne(), false); loader != nil {
 			method.Loaders = append(method.Loaders, loader)
 		}
@@ -166,7 +220,7 @@ func wrapMethods(service *Service) {
 	}
 }
 
-func wrapMessage(file *File, proto *descriptorpb.DescriptorProto, parent *Message) {
+func wrapMessage(file *File, proto *descriptorpb.DescriptorProto, parent *Message, path []int32) {
 	typeName := calculateTypeName(proto.GetName(), parent)
 	msg := &Message{
 		Proto:    proto,
@@ -177,35 +231,43 @@ func wrapMessage(file *File, proto *descriptorpb.DescriptorProto, parent *Messag
 		IsMap:    proto.GetOptions().GetMapEntry(),
 		TypeName: typeName,
 		FullName: fmt.Sprintf(".%s.%s", file.Proto.GetPackage(), strings.Join(typeName, ".")),
+		Comments: file.comments(path),
+		Location: file.location(path),
 	}
 	file.Messages = append(file.Messages, msg)
 	if parent != nil {
 		parent.Nested = append(parent.Nested, msg)
 	}
 
-	wrapFields(msg)
-	wrapOneofs(msg)
-	for _, nested := range proto.GetNestedType() {
-		wrapMessage(file, nested, msg)
+	wrapFields(msg, path)
+	wrapOneofs(msg, path)
+	for i, nested := range proto.GetNestedType() {
+		wrapMessage(file, nested, msg, childPath(path, messageNestedPath, i))
 	}
-	for _, enum := range proto.GetEnumType() {
-		wrapEnum(file, enum, msg)
+	for i, enum := range proto.GetEnumType() {
+		wrapEnum(file, enum, msg, childPath(path, messageEnumPath, i))
 	}
 }
 
-func wrapFields(parent *Message) {
+func wrapFields(parent *Message, parentPath []int32) {
 	seenOneofs := make(map[int32]bool)
-	for _, fieldProto := range parent.Proto.GetField() {
-		// Handle normal field.
-		if fieldProto.OneofIndex == nil {
-			options := getFieldOptions(fieldProto)
-			parent.Fields = append(parent.Fields, &Field{
-				Name:       fieldProto.GetName(),
-				Proto:      fieldProto,
-				Options:    options,
-				Parent:     parent,
-				ForeignKey: getForeignKeyOption(options.GetForeignKey()),
-			})
+	for i, fieldProto := range parent.Proto.GetField() {
+		// Handle normal field, including proto3 optional fields which belong
+		// to a synthetic oneof.
+		if fieldProto.OneofIndex == nil || fieldProto.GetProto3Optional() {
+			path := childPath(parentPath, messageFieldPath, i)
+			field := &Field{
+				Name:     fieldProto.GetName(),
+				Proto:    fieldProto,
+				Options:  getFieldOptions(fieldProto),
+				Parent:   parent,
+				Presence: resolveFieldPresence(fieldProto, parent),
+				Comments: parent.File.comments(path),
+				Location: parent.File.location(path),
+			}
+			applyFieldBehaviors(field)
+			field.ForeignKey = getForeignKeyOption(field)
+			parent.Fields = append(parent.Fields, field)
 			continue
 		}
 
@@ -217,45 +279,70 @@ func wrapFields(parent *Message) {
 		}
 		seenOneofs[index] = true
 
+		path := childPath(parentPath, messageOneofPath, int(index))
 		name := parent.Proto.GetOneofDecl()[index].GetName()
 		parent.Fields = append(parent.Fields, &Field{
 			Name:       name,
 			Parent:     parent,
 			IsOneof:    true,
 			OneofIndex: index,
+			Comments:   parent.File.comments(path),
+			Location:   parent.File.location(path),
 		})
 	}
 }
 
-func wrapOneofs(parent *Message) {
-	for _, oneofProto := range parent.Proto.GetOneofDecl() {
+func wrapOneofs(parent *Message, parentPath []int32) {
+	// Synthetic oneofs are always declared after all real oneofs, so the
+	// indexes of real oneofs are unaffected by skipping them.
+	synthetic := make(map[int32]bool)
+	for _, fieldProto := range parent.Proto.GetField() {
+		if fieldProto.GetProto3Optional() {
+			synthetic[fieldProto.GetOneofIndex()] = true
+		}
+	}
+
+	for i, oneofProto := range parent.Proto.GetOneofDecl() {
+		if synthetic[int32(i)] {
+			continue
+		}
 		parent.Oneofs = append(parent.Oneofs, &Oneof{
-			Proto:  oneofProto,
-			Parent: parent,
+			Proto:    oneofProto,
+			Parent:   parent,
+			Location: parent.File.location(childPath(parentPath, messageOneofPath, i)),
 		})
 	}
 
-	for _, fieldProto := range parent.Proto.GetField() {
-		if fieldProto.OneofIndex != nil {
+	for i, fieldProto := range parent.Proto.GetField() {
+		if fieldProto.OneofIndex != nil && !fieldProto.GetProto3Optional() {
 			index := *fieldProto.OneofIndex
-			parent.Oneofs[index].Fields = append(parent.Oneofs[index].Fields, &Field{
-				Name:    fieldProto.GetName(),
-				Proto:   fieldProto,
-				Options: getFieldOptions(fieldProto),
-				Parent:  parent,
-			})
+			path := childPath(parentPath, messageFieldPath, i)
+			field := &Field{
+				Name:     fieldProto.GetName(),
+				Proto:    fieldProto,
+				Options:  getFieldOptions(fieldProto),
+				Parent:   parent,
+				Presence: resolveFieldPresence(fieldProto, parent),
+				Comments: parent.File.comments(path),
+				Location: parent.File.location(path),
+			}
+			applyFieldBehaviors(field)
+			parent.Oneofs[index].Fields = append(parent.Oneofs[index].Fields, field)
 		}
 	}
 }
 
-func wrapEnum(file *File, proto *descriptorpb.EnumDescriptorProto, parent *Message) {
+func wrapEnum(file *File, proto *descriptorpb.EnumDescriptorProto, parent *Message, path []int32) {
 	typeName := calculateTypeName(proto.GetName(), parent)
 
 	var values []*EnumValue
-	for _, valueProto := range proto.GetValue() {
+	for i, valueProto := range proto.GetValue() {
+		valuePath := childPath(path, enumValuePath, i)
 		values = append(values, &EnumValue{
-			Proto:   valueProto,
-			Options: getEnumValueOptions(valueProto),
+			Proto:    valueProto,
+			Options:  getEnumValueOptions(valueProto),
+			Comments: file.comments(valuePath),
+			Location: file.location(valuePath),
 		})
 	}
 
@@ -268,6 +355,8 @@ func wrapEnum(file *File, proto *descriptorpb.EnumDescriptorProto, parent *Messa
 		Values:   values,
 		TypeName: typeName,
 		FullName: fmt.Sprintf(".%s.%s", file.Proto.GetPackage(), strings.Join(typeName, ".")),
+		Comments: file.comments(path),
+		Location: file.location(path),
 	}
 	file.Enums = append(file.Enums, enum)
 	if parent != nil {
@@ -275,138 +364,35 @@ func wrapEnum(file *File, proto *descriptorpb.EnumDescriptorProto, parent *Messa
 	}
 }
 
-func setComments(file *File) {
-	for _, location := range file.Proto.GetSourceCodeInfo().GetLocation() {
-		if location.GetLeadingComments() == "" && location.GetTrailingComments() == "" {
-			continue
-		}
-
-		// We need at least 2 elements in the path to describe a definition.
-		// The first identifies the file's field number, and the second identifies
-		// the index within the field.
-		if len(location.Path) < 2 {
-			continue
-		}
-
-		switch location.Path[0] {
-		case 4: // Message
-			messageProto := file.Proto.MessageType[location.Path[1]]
-			setMessageComments(file, messageProto, location, location.Path[2:])
-		case 5: // Enum
-			enumProto := file.Proto.EnumType[location.Path[1]]
-			setEnumComments(file, enumProto, location, location.Path[2:])
-		case 6: // Service
-			var service *Service
-			serviceProto := file.Proto.Service[location.Path[1]]
-			for _, s := range file.Services {
-				if s.Proto == serviceProto {
-					service = s
-				}
-			}
-
-			if len(location.Path) == 2 {
-				// This is a comment for the service.
-				service.Comments = combineComments(location)
-				continue
-			}
-
-			switch location.Path[2] {
-			case 2: // Method
-				var method *Method
-				enumValueProto := service.Proto.Method[location.Path[3]]
-				for _, m := range service.Methods {
-					if m.Proto == enumValueProto {
-						method = m
-					}
-				}
-
-				if len(location.Path) == 4 {
-					// This is a comment for the method.
-					method.Comments = combineComments(location)
-				}
-			}
-		}
+// location returns the location of the definition at the source code info
+// path. Only the file is known if the file has no source code info.
+func (f *File) location(path []int32) diagnostics.Location {
+	location := diagnostics.Location{File: f.Proto.GetName()}
+	// Spans are zero-based, and start with the line and column of the
+	// definition.
+	if span := f.locations[pathKey(path)].GetSpan(); len(span) >= 2 {
+		location.Line = int(span[0]) + 1
+		location.Column = int(span[1]) + 1
 	}
+	return location
 }
 
-func setMessageComments(file *File, proto *descriptorpb.DescriptorProto, location *descriptorpb.SourceCodeInfo_Location, relativePath []int32) {
-	var message *Message
-	for _, m := range file.Messages {
-		if m.Proto == proto {
-			message = m
-		}
-	}
-
-	if len(relativePath) == 0 {
-		// This is a comment for the message.
-		message.Comments = combineComments(location)
-		return
-	}
-
-	switch relativePath[0] {
-	case 2: // Field
-		var field *Field
-		fieldProto := message.Proto.Field[relativePath[1]]
-
-		fields := message.Fields
-		if fieldProto.OneofIndex != nil {
-			oneof := message.Oneofs[fieldProto.GetOneofIndex()]
-			fields = oneof.Fields
-		}
-		for _, f := range fields {
-			if f.Proto == fieldProto {
-				field = f
-			}
-		}
-
-		if len(relativePath) == 2 {
-			// This is a comment for the field.
-			field.Comments = combineComments(location)
-		}
-	case 3: // Nested message
-		messageProto := message.Proto.NestedType[relativePath[1]]
-		setMessageComments(file, messageProto, location, relativePath[2:])
-	case 4: // Nested enum
-		enumProto := message.Proto.EnumType[relativePath[1]]
-		setEnumComments(file, enumProto, location, relativePath[2:])
-	case 8: // Oneof
-		for _, f := range message.Fields {
-			if f.IsOneof && f.OneofIndex == relativePath[1] {
-				f.Comments = combineComments(location)
-			}
-		}
-	}
+// comments returns the comments of the definition at the source code info
+// path.
+func (f *File) comments(path []int32) string {
+	return combineComments(f.locations[pathKey(path)])
 }
 
-func setEnumComments(file *File, proto *descriptorpb.EnumDescriptorProto, location *descriptorpb.SourceCodeInfo_Location, relativePath []int32) {
-	var enum *Enum
-	for _, e := range file.Enums {
-		if e.Proto == proto {
-			enum = e
-		}
-	}
-
-	if len(relativePath) == 0 {
-		// This is a comment for the enum.
-		enum.Comments = combineComments(location)
-		return
-	}
-
-	switch relativePath[0] {
-	case 2: // Enum value
-		var enumValue *EnumValue
-		enumValueProto := enum.Proto.Value[relativePath[1]]
-		for _, v := range enum.Values {
-			if v.Proto == enumValueProto {
-				enumValue = v
-			}
-		}
+func pathKey(path []int32) string {
+	return fmt.Sprint(path)
+}
 
-		if len(relativePath) == 2 {
-			// This is a comment for the enum value.
-			enumValue.Comments = combineComments(location)
-		}
-	}
+// childPath returns the source code info path of the element at the index of
+// a repeated field of the definition at the parent path.
+func childPath(parent []int32, field int32, index int) []int32 {
+	path := make([]int32, len(parent), len(parent)+2)
+	copy(path, parent)
+	return append(path, field, int32(index))
 }
 
 func calculateTypeName(name string, parent *Message) []string {
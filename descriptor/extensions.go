@@ -36,14 +36,47 @@ func getMessageOptions(message *descriptorpb.DescriptorProto) *graphqlpb.Message
 
 func getFieldOptions(field *descriptorpb.FieldDescriptorProto) *graphqlpb.FieldOptions {
 	options := field.GetOptions()
+	fieldOptions := &graphqlpb.FieldOptions{}
 	if proto.HasExtension(options, graphqlpb.E_Field) {
 		ext, err := proto.GetExtension(options, graphqlpb.E_Field)
 		if err != nil {
 			panic(fmt.Sprintf("error getting field options: %s", err.Error()))
 		}
-		return ext.(*graphqlpb.FieldOptions)
+		fieldOptions = ext.(*graphqlpb.FieldOptions)
 	}
-	return &graphqlpb.FieldOptions{}
+	return fieldOptions
+}
+
+// applyFieldBehaviors sets the options of a field that are equivalent to its
+// google.api.field_behavior annotations.
+func applyFieldBehaviors(field *Field) {
+	behaviors, err := getFieldBehaviors(field.Proto.GetOptions())
+	if err != nil {
+		field.Parent.File.diagnostics.Errorf(field.Location, "%s", err.Error())
+		return
+	}
+
+	for _, behavior := range behaviors {
+		switch behavior {
+		case fieldBehaviorRequired:
+			field.Options.Required = true
+		case fieldBehaviorOutputOnly:
+			field.Options.SkipInput = true
+		case fieldBehaviorInputOnly:
+			field.Options.SkipOutput = true
+		}
+	}
+}
+
+// getHTTPMethodOption returns the HTTP method of a method's google.api.http
+// rule, or an empty string if it has none or the rule can't be parsed.
+func getHTTPMethodOption(method *Method) string {
+	httpMethod, err := getHTTPMethod(method.Proto.GetOptions())
+	if err != nil {
+		method.Service.File.diagnostics.Errorf(method.Location, "%s", err.Error())
+		return ""
+	}
+	return httpMethod
 }
 
 func getEnumOptions(enum *descriptorpb.EnumDescriptorProto) *graphqlpb.EnumOptions {
@@ -94,14 +127,17 @@ func getMethodOptions(method *descriptorpb.MethodDescriptorProto) *graphqlpb.Met
 	return &graphqlpb.MethodOptions{}
 }
 
-func getForeignKeyOption(value string) *ForeignKey {
+func getForeignKeyOption(field *Field) *ForeignKey {
+	value := field.Options.GetForeignKey()
 	if value == "" {
 		return nil
 	}
 
 	parts := strings.Split(value, ":")
 	if len(parts) != 2 {
-		panic(fmt.Sprintf("Foreign key expected to have format 'protobuf_type:field_name', got %s", value))
+		field.Parent.File.diagnostics.Errorf(field.Location,
+			"foreign key expected to have format 'protobuf_type:field_name', got %s", value)
+		return nil
 	}
 
 	fullName := parts[0]
@@ -122,8 +158,15 @@ func getLoaderOption(method *Method, value string, many bool) *Loader {
 	}
 
 	parts := strings.Split(value, ":")
-	if len(parts) != 4 {
-		panic(fmt.Sprintf("Loader expected to have format 'protobuf_type:request_field_path:response_field_path:object_key_field_path', got %s", value))
+	// The object key field path is only needed to order the results of
+	// load_many methods, so it may be omitted for load_one methods.
+	if len(parts) == 3 && !many {
+		parts = append(parts, "")
+	}
+	if len(parts) != 4 || (many && parts[3] == "") {
+		method.Service.File.diagnostics.Errorf(method.Location,
+			"loader expected to have format 'protobuf_type:request_field_path:response_field_path:object_key_field_path', got %s", value)
+		return nil
 	}
 
 	fullName := parts[0]
@@ -132,12 +175,15 @@ func getLoaderOption(method *Method, value string, many bool) *Loader {
 		fullName = "." + fullName
 	}
 
-	return &Loader{
-		FullName:           fullName,
-		Many:               many,
-		RequestFieldPath:   strings.Split(parts[1], "."),
-		ResponseFieldPath:  strings.Split(parts[2], "."),
-		ObjectKeyFieldPath: strings.Split(parts[3], "."),
-		Method:             method,
+	loader := &Loader{
+		FullName:          fullName,
+		Many:              many,
+		RequestFieldPath:  strings.Split(parts[1], "."),
+		ResponseFieldPath: strings.Split(parts[2], "."),
+		Method:            method,
+	}
+	if parts[3] != "" {
+		loader.ObjectKeyFieldPath = strings.Split(parts[3], ".")
 	}
+	return loader
 }
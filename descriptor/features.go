@@ -0,0 +1,57 @@
+package descriptor
+
+import (
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fileEdition returns the edition of the file, where the proto2 and proto3
+// syntaxes are represented by their equivalent editions.
+func fileEdition(file *descriptorpb.FileDescriptorProto) descriptorpb.Edition {
+	switch file.GetSyntax() {
+	case "proto2", "":
+		return descriptorpb.Edition_EDITION_PROTO2
+	case "proto3":
+		return descriptorpb.Edition_EDITION_PROTO3
+	}
+	return file.GetEdition()
+}
+
+// defaultFieldPresence returns the field presence used by an edition when it
+// is not overridden by the features of the file or field.
+func defaultFieldPresence(edition descriptorpb.Edition) descriptorpb.FeatureSet_FieldPresence {
+	if edition == descriptorpb.Edition_EDITION_PROTO3 {
+		return descriptorpb.FeatureSet_IMPLICIT
+	}
+	return descriptorpb.FeatureSet_EXPLICIT
+}
+
+// resolveFieldPresence returns the effective presence of a field. The
+// 'field_presence' feature can only be set on fields and files, so it is
+// resolved from the field and then its file, before falling back to the
+// edition's default.
+// The proto2 'required' label and the proto3 'optional' label have the same
+// semantics as LEGACY_REQUIRED and EXPLICIT presence respectively.
+//
+// Repeated fields do not track presence and are always IMPLICIT, whereas
+// message fields are always EXPLICIT. Members of real oneofs are resolved
+// like other fields, as their presence is represented by the oneof itself.
+func resolveFieldPresence(proto *descriptorpb.FieldDescriptorProto, parent *Message) descriptorpb.FeatureSet_FieldPresence {
+	switch {
+	case proto.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED:
+		return descriptorpb.FeatureSet_IMPLICIT
+	case proto.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED:
+		return descriptorpb.FeatureSet_LEGACY_REQUIRED
+	case proto.GetProto3Optional(),
+		proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return descriptorpb.FeatureSet_EXPLICIT
+	}
+
+	if features := proto.GetOptions().GetFeatures(); features != nil && features.FieldPresence != nil {
+		return features.GetFieldPresence()
+	}
+	if features := parent.File.Proto.GetOptions().GetFeatures(); features != nil && features.FieldPresence != nil {
+		return features.GetFieldPresence()
+	}
+	return defaultFieldPresence(parent.File.Edition)
+}
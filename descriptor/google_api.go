@@ -0,0 +1,161 @@
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Field numbers and values of the google.api annotations. The annotations are
+// not a dependency of this plugin, so they are decoded from the wire format.
+const (
+	fieldBehaviorExtension protowire.Number = 1052
+	httpExtension          protowire.Number = 72295728
+
+	fieldBehaviorRequired   protoreflect.EnumNumber = 2
+	fieldBehaviorOutputOnly protoreflect.EnumNumber = 3
+	fieldBehaviorInputOnly  protoreflect.EnumNumber = 4
+)
+
+// Field numbers of the HTTP method patterns in google.api.HttpRule.
+var httpRuleMethods = map[protowire.Number]string{
+	2: "GET",
+	3: "PUT",
+	4: "POST",
+	5: "DELETE",
+	6: "PATCH",
+}
+
+const (
+	httpRuleCustom    protowire.Number = 8
+	customPatternKind protowire.Number = 1
+)
+
+// getFieldBehaviors returns the values of the google.api.field_behavior
+// extension of a field.
+func getFieldBehaviors(options *descriptorpb.FieldOptions) ([]protoreflect.EnumNumber, error) {
+	values, err := extensionValues(options, fieldBehaviorExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	var behaviors []protoreflect.EnumNumber
+	for _, value := range values {
+		switch value.typ {
+		case protowire.VarintType:
+			v, _ := protowire.ConsumeVarint(value.b)
+			behaviors = append(behaviors, protoreflect.EnumNumber(v))
+		case protowire.BytesType:
+			// Packed repeated values.
+			packed := value.b
+			for len(packed) > 0 {
+				v, n := protowire.ConsumeVarint(packed)
+				if n < 0 {
+					return nil, fmt.Errorf("error parsing field_behavior: %s", protowire.ParseError(n).Error())
+				}
+				behaviors = append(behaviors, protoreflect.EnumNumber(v))
+				packed = packed[n:]
+			}
+		}
+	}
+	return behaviors, nil
+}
+
+// getHTTPMethod returns the HTTP method of the google.api.http rule of a gRPC
+// method, e.g. "GET", or the upper cased kind of a custom pattern. Additional
+// bindings are ignored. Returns an empty string if the method has no rule.
+func getHTTPMethod(options *descriptorpb.MethodOptions) (string, error) {
+	rules, err := extensionValues(options, httpExtension)
+	if err != nil {
+		return "", err
+	}
+
+	var httpMethod string
+	for _, rule := range rules {
+		values, err := fieldValues(rule.b)
+		if err != nil {
+			return "", fmt.Errorf("error parsing google.api.http: %s", err.Error())
+		}
+		for _, value := range values {
+			if method, ok := httpRuleMethods[value.num]; ok {
+				httpMethod = method
+			}
+			if value.num != httpRuleCustom {
+				continue
+			}
+			kinds, err := fieldValues(value.b)
+			if err != nil {
+				return "", fmt.Errorf("error parsing google.api.http: %s", err.Error())
+			}
+			for _, kind := range kinds {
+				if kind.num == customPatternKind {
+					httpMethod = strings.ToUpper(string(kind.b))
+				}
+			}
+		}
+	}
+	return httpMethod, nil
+}
+
+// fieldValue is a field value in the protobuf wire format. For varints, b is
+// the encoded varint, and for length-delimited values, b is the content.
+type fieldValue struct {
+	num protowire.Number
+	typ protowire.Type
+	b   []byte
+}
+
+// extensionValues returns the values of an extension field of an options
+// message. Extensions that this plugin does not know about are either
+// resolved from the request's files if they are imported, or otherwise kept
+// as unknown fields, so the options are marshaled to find them in both cases.
+func extensionValues(options protoreflect.ProtoMessage, num protowire.Number) ([]fieldValue, error) {
+	if !options.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	b, err := proto.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling options: %s", err.Error())
+	}
+
+	all, err := fieldValues(b)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing options: %s", err.Error())
+	}
+	var values []fieldValue
+	for _, value := range all {
+		if value.num == num {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// fieldValues returns all the field values of a message in the protobuf wire
+// format.
+func fieldValues(b []byte) ([]fieldValue, error) {
+	var values []fieldValue
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		value := fieldValue{num: num, typ: typ, b: b[:n]}
+		if typ == protowire.BytesType {
+			value.b, _ = protowire.ConsumeBytes(b)
+		}
+		values = append(values, value)
+		b = b[n:]
+	}
+	return values, nil
+}
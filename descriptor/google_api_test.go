@@ -0,0 +1,80 @@
+package descriptor
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGetFieldBehaviorsFromUnknownFields(t *testing.T) {
+	var b []byte
+	// Unpacked values.
+	b = protowire.AppendTag(b, fieldBehaviorExtension, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(fieldBehaviorRequired))
+	// Unrelated fields are ignored.
+	b = protowire.AppendTag(b, 82731, protowire.BytesType)
+	b = protowire.AppendBytes(b, []byte{0x08, 0x01})
+	// Packed values.
+	b = protowire.AppendTag(b, fieldBehaviorExtension, protowire.BytesType)
+	b = protowire.AppendBytes(b, []byte{byte(fieldBehaviorOutputOnly), byte(fieldBehaviorInputOnly)})
+
+	options := &descriptorpb.FieldOptions{}
+	options.ProtoReflect().SetUnknown(b)
+
+	behaviors, err := getFieldBehaviors(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []protoreflect.EnumNumber{fieldBehaviorRequired, fieldBehaviorOutputOnly, fieldBehaviorInputOnly}
+	if !reflect.DeepEqual(behaviors, expected) {
+		t.Errorf("got %v; want %v", behaviors, expected)
+	}
+}
+
+func TestGetHTTPMethodFromUnknownFields(t *testing.T) {
+	var testCases = []struct {
+		rule []byte
+		out  string
+	}{
+		{protowire.AppendString(protowire.AppendTag(nil, 2, protowire.BytesType), "/v1/books"), "GET"},
+		{protowire.AppendString(protowire.AppendTag(nil, 6, protowire.BytesType), "/v1/books"), "PATCH"},
+		{
+			protowire.AppendBytes(protowire.AppendTag(nil, httpRuleCustom, protowire.BytesType),
+				protowire.AppendString(protowire.AppendTag(nil, customPatternKind, protowire.BytesType), "head")),
+			"HEAD",
+		},
+		{nil, ""},
+	}
+	for _, testCase := range testCases {
+		options := &descriptorpb.MethodOptions{}
+		if testCase.rule != nil {
+			b := protowire.AppendTag(nil, httpExtension, protowire.BytesType)
+			options.ProtoReflect().SetUnknown(protowire.AppendBytes(b, testCase.rule))
+		}
+
+		s, err := getHTTPMethod(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != testCase.out {
+			t.Errorf("got %s; want %s", s, testCase.out)
+		}
+	}
+}
+
+func TestGetFieldBehaviorsFromMalformedOptions(t *testing.T) {
+	var b []byte
+	// Packed values with a truncated varint.
+	b = protowire.AppendTag(b, fieldBehaviorExtension, protowire.BytesType)
+	b = protowire.AppendBytes(b, []byte{byte(fieldBehaviorRequired), 0x80})
+
+	options := &descriptorpb.FieldOptions{}
+	options.ProtoReflect().SetUnknown(b)
+
+	if _, err := getFieldBehaviors(options); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -0,0 +1,121 @@
+// Package diagnostics collects the errors and warnings found while generating
+// GraphQL schemas, along with the location in the Protobuf source files of
+// the definitions that they relate to.
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+// Location is a position in a Protobuf source file. Line and Column are
+// 1-based, and are 0 if the file has no source code info for the position.
+type Location struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (l Location) String() string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+}
+
+type Diagnostic struct {
+	Severity Severity
+	Location Location
+	Message  string
+}
+
+// String returns the diagnostic in the format used by the Protobuf compiler,
+// e.g. "users.proto:12:3: unknown type for foreign key: ...".
+func (d *Diagnostic) String() string {
+	if d.Severity == SeverityWarning {
+		return fmt.Sprintf("%s: warning: %s", d.Location, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Location, d.Message)
+}
+
+// Collector collects diagnostics, so that all problems can be reported at
+// once instead of failing on the first one. The zero value is ready to use.
+type Collector struct {
+	diagnostics []*Diagnostic
+}
+
+// Errorf records an error at the location.
+func (c *Collector) Errorf(location Location, format string, args ...interface{}) {
+	c.add(SeverityError, location, fmt.Sprintf(format, args...))
+}
+
+// Warnf records a warning at the location.
+func (c *Collector) Warnf(location Location, format string, args ...interface{}) {
+	c.add(SeverityWarning, location, fmt.Sprintf(format, args...))
+}
+
+func (c *Collector) add(severity Severity, location Location, message string) {
+	d := &Diagnostic{
+		Severity: severity,
+		Location: location,
+		Message:  message,
+	}
+	// The same definition may be mapped more than once, e.g. to both object
+	// and input types, so duplicates are only recorded once.
+	for _, other := range c.diagnostics {
+		if *other == *d {
+			return
+		}
+	}
+	c.diagnostics = append(c.diagnostics, d)
+}
+
+// Diagnostics returns the recorded diagnostics ordered by their location.
+// Diagnostics at the same location are kept in the order they were recorded.
+func (c *Collector) Diagnostics() []*Diagnostic {
+	diagnostics := append([]*Diagnostic(nil), c.diagnostics...)
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Location, diagnostics[j].Location
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diagnostics
+}
+
+// HasErrors returns whether any errors were recorded.
+func (c *Collector) HasErrors() bool {
+	for _, d := range c.diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns an error listing all recorded diagnostics, one per line, or nil
+// if no errors were recorded.
+func (c *Collector) Err() error {
+	if !c.HasErrors() {
+		return nil
+	}
+
+	var lines []string
+	for _, d := range c.Diagnostics() {
+		lines = append(lines, d.String())
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,24 @@
+package diagnostics
+
+import (
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	c := &Collector{}
+	c.Warnf(Location{File: "b.proto", Line: 3, Column: 1}, "unused %s", "option")
+	if err := c.Err(); err != nil {
+		t.Errorf("expected no error for warnings, got %v", err)
+	}
+
+	c.Errorf(Location{File: "b.proto", Line: 1, Column: 5}, "invalid %s", "operation")
+	c.Errorf(Location{File: "a.proto"}, "unknown type")
+	c.Errorf(Location{File: "b.proto", Line: 1, Column: 5}, "invalid %s", "operation")
+
+	expected := "a.proto: unknown type\n" +
+		"b.proto:1:5: invalid operation\n" +
+		"b.proto:3:1: warning: unused option"
+	if err := c.Err(); err == nil || err.Error() != expected {
+		t.Errorf("got %v; want %s", err, expected)
+	}
+}
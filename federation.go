@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+)
+
+const federationV2URL = "https://specs.apollo.dev/federation/v2.3"
+
+// federationTypes returns the types that make the generated schema an Apollo
+// Federation v2 subgraph: the @link schema extension that imports the
+// federation directives, the _Entity union of all objects with keys, and the
+// _entities and _service root fields.
+func (g *Generator) federationTypes() []graphql.Type {
+	var entities []string
+	for _, fileName := range g.req.GetFileToGenerate() {
+		for _, message := range g.mapper.Files[fileName].Messages {
+			m, ok := g.mapper.MessageMappers[message.FullName]
+			if !ok {
+				continue // Map entry was mapped to a scalar
+			}
+			if m.Object != nil && len(m.Keys) > 0 {
+				entities = append(entities, m.Object.Name)
+			}
+		}
+	}
+
+	gqlTypes := []graphql.Type{
+		&graphql.ExtendSchema{
+			Directives: []string{
+				fmt.Sprintf(`link(url: "%s", import: ["@key", "@shareable"])`, federationV2URL),
+			},
+		},
+		&graphql.Scalar{Name: "_Any"},
+	}
+
+	query := &graphql.ExtendObject{Name: "Query"}
+	if len(entities) > 0 {
+		gqlTypes = append(gqlTypes, &graphql.Union{
+			Name:      "_Entity",
+			TypeNames: entities,
+		})
+		query.Fields = append(query.Fields, &graphql.Field{
+			Name:     "_entities",
+			TypeName: "_Entity",
+			Arguments: []*graphql.Argument{
+				{
+					Name:      "representations",
+					TypeName:  "_Any",
+					Modifiers: graphql.TypeModifierNonNull | graphql.TypeModifierList | graphql.TypeModifierNonNullList,
+				},
+			},
+			Modifiers: graphql.TypeModifierList | graphql.TypeModifierNonNullList,
+		})
+	}
+	query.Fields = append(query.Fields, &graphql.Field{
+		Name:      "_service",
+		TypeName:  "_Service",
+		Modifiers: graphql.TypeModifierNonNull,
+	})
+
+	return append(gqlTypes,
+		&graphql.Object{
+			Name: "_Service",
+			Fields: []*graphql.Field{
+				{Name: "sdl", TypeName: graphql.ScalarString.TypeName()},
+			},
+		},
+		query,
+	)
+}
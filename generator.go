@@ -3,11 +3,14 @@ package main
 import (
 	"fmt"
 	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+	"os"
 	"strings"
 
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 
+	"github.com/martinxsliu/protoc-gen-graphql/diagnostics"
 	"github.com/martinxsliu/protoc-gen-graphql/graphql"
 	"github.com/martinxsliu/protoc-gen-graphql/mapper"
 )
@@ -15,18 +18,27 @@ import (
 var header = []byte(`# DO NOT EDIT! Generated by protoc-gen-graphql.`)
 
 type Generator struct {
-	req    *pluginpb.CodeGeneratorRequest
-	gen    *protogen.Plugin
-	mapper *mapper.Mapper
+	req         *pluginpb.CodeGeneratorRequest
+	gen         *protogen.Plugin
+	mapper      *mapper.Mapper
+	diagnostics *diagnostics.Collector
 }
 
 func New(gen *protogen.Plugin) *Generator {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL |
+		pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+	gen.SupportedEditionsMinimum = descriptorpb.Edition_EDITION_PROTO2
+	gen.SupportedEditionsMaximum = descriptorpb.Edition_EDITION_2023
 	return &Generator{
-		req: gen.Request,
-		gen: gen,
+		req:         gen.Request,
+		gen:         gen,
+		diagnostics: &diagnostics.Collector{},
 	}
 }
 
+// Generate generates the output files. If any errors are found, the returned
+// error lists all errors and warnings with their locations in the Protobuf
+// source files. Otherwise, warnings are written to stderr.
 func (g *Generator) Generate() (err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -39,81 +51,125 @@ func (g *Generator) Generate() (err error) {
 		return err
 	}
 
-	g.mapper = mapper.New(g.req.GetProtoFile(), params)
+	g.mapper = mapper.New(g.req.GetProtoFile(), params, g.diagnostics)
+	if err := g.diagnostics.Err(); err != nil {
+		return err
+	}
+	g.validateSchema(params)
+	if err := g.diagnostics.Err(); err != nil {
+		return err
+	}
+
 	g.generateFiles(params)
+	if params.ScalarsFileName != "" {
+		g.generateScalars(params)
+	}
+	if params.ManifestFileName != "" {
+		g.generateManifest(params)
+	}
+	if params.Loaders == parameters.LoadersGo {
+		g.generateLoaders()
+	}
+	if err := g.diagnostics.Err(); err != nil {
+		return err
+	}
+
+	for _, d := range g.diagnostics.Diagnostics() {
+		fmt.Fprintln(os.Stderr, d)
+	}
 	return nil
 }
 
 func (g *Generator) generateFiles(params *parameters.Parameters) {
-	for _, fileName := range g.req.GetFileToGenerate() {
-		fileResp := &pluginpb.CodeGeneratorResponse_File{}
-		fileResp.Name = stringPtr(graphqlFileName(fileName))
+	for _, group := range g.outputGroups(params) {
+		g.writeFile(group.fileName, group.types, params)
+	}
+}
 
-		var gqlTypes []graphql.Type
-		file := g.mapper.Files[fileName]
+// fileTypes returns the GraphQL types that are generated for a protobuf file.
+func (g *Generator) fileTypes(fileName string) []graphql.Type {
+	var gqlTypes []graphql.Type
+	file := g.mapper.Files[fileName]
 
-		for _, service := range file.Services {
-			m, ok := g.mapper.ServiceMappers[service.FullName]
-			if !ok {
-				continue // Service was skipped
-			}
+	for _, service := range file.Services {
+		m, ok := g.mapper.ServiceMappers[service.FullName]
+		if !ok {
+			continue // Service was skipped
+		}
 
-			if m.Queries != nil {
-				if m.Queries.ExtendRootObject != nil {
-					gqlTypes = append(gqlTypes, m.Queries.ExtendRootObject)
-				}
-				gqlTypes = append(gqlTypes, m.Queries.Object)
+		if m.Queries != nil {
+			if m.Queries.ExtendRootObject != nil {
+				gqlTypes = append(gqlTypes, m.Queries.ExtendRootObject)
 			}
-			if m.Mutations != nil {
-				if m.Mutations.ExtendRootObject != nil {
-					gqlTypes = append(gqlTypes, m.Mutations.ExtendRootObject)
-				}
-				gqlTypes = append(gqlTypes, m.Mutations.Object)
+			gqlTypes = append(gqlTypes, m.Queries.Object)
+		}
+		if m.Mutations != nil {
+			if m.Mutations.ExtendRootObject != nil {
+				gqlTypes = append(gqlTypes, m.Mutations.ExtendRootObject)
 			}
-			if m.Subscriptions != nil {
-				if m.Subscriptions.ExtendRootObject != nil {
-					gqlTypes = append(gqlTypes, m.Subscriptions.ExtendRootObject)
-				}
-				gqlTypes = append(gqlTypes, m.Subscriptions.Object)
+			gqlTypes = append(gqlTypes, m.Mutations.Object)
+		}
+		if m.Subscriptions != nil {
+			if m.Subscriptions.ExtendRootObject != nil {
+				gqlTypes = append(gqlTypes, m.Subscriptions.ExtendRootObject)
 			}
+			gqlTypes = append(gqlTypes, m.Subscriptions.Object)
 		}
 
-		for _, message := range file.Messages {
-			m := g.mapper.MessageMappers[message.FullName]
-
-			if m.Object != nil {
-				gqlTypes = append(gqlTypes, m.Object)
+		for _, methods := range []*mapper.MethodsMapper{m.Queries, m.Mutations, m.Subscriptions} {
+			if methods == nil {
+				continue
 			}
-			for _, oneof := range m.Oneofs {
-				gqlTypes = append(gqlTypes, oneof.Union)
-				for _, object := range oneof.Objects {
-					gqlTypes = append(gqlTypes, object)
+			for _, method := range methods.Methods {
+				if connection, ok := g.mapper.Connections[method]; ok {
+					gqlTypes = append(gqlTypes, connection.Connection, connection.Edge, g.mapper.PageInfo)
 				}
 			}
+		}
+	}
 
-			if m.Input != nil {
-				gqlTypes = append(gqlTypes, m.Input)
-			}
-			for _, oneof := range m.Oneofs {
-				if oneof.Input != nil {
-					gqlTypes = append(gqlTypes, oneof.Input)
-				}
+	for _, message := range file.Messages {
+		m, ok := g.mapper.MessageMappers[message.FullName]
+		if !ok {
+			continue // Map entry was mapped to a scalar
+		}
+
+		if m.Object != nil {
+			gqlTypes = append(gqlTypes, m.Object)
+		}
+		for _, oneof := range m.Oneofs {
+			gqlTypes = append(gqlTypes, oneof.Type())
+			for _, object := range oneof.Objects {
+				gqlTypes = append(gqlTypes, object)
 			}
 		}
 
-		for _, enum := range file.Enums {
-			gqlTypes = append(gqlTypes, g.mapper.EnumMappers[enum.FullName].Enum)
+		if m.Input != nil {
+			gqlTypes = append(gqlTypes, m.Input)
+		}
+		for _, oneof := range m.Oneofs {
+			if oneof.Input != nil {
+				gqlTypes = append(gqlTypes, oneof.Input)
+			}
 		}
+	}
 
-		genFile := g.gen.NewGeneratedFile(graphqlFileName(fileName), "github.com/not-a-real-import")
+	for _, enum := range file.Enums {
+		gqlTypes = append(gqlTypes, g.mapper.EnumMappers[enum.FullName].Enum)
+	}
 
-		_, _ = genFile.Write(header)
-		for _, gqlType := range gqlTypes {
-			_, _ = genFile.Write([]byte("\n\n"))
-			_, _ = genFile.Write([]byte(graphql.TypeDef(gqlType, params)))
-		}
-		_, _ = genFile.Write([]byte("\n"))
+	return gqlTypes
+}
+
+func (g *Generator) writeFile(name string, gqlTypes []graphql.Type, params *parameters.Parameters) {
+	genFile := g.gen.NewGeneratedFile(name, "github.com/not-a-real-import")
+
+	_, _ = genFile.Write(header)
+	for _, gqlType := range gqlTypes {
+		_, _ = genFile.Write([]byte("\n\n"))
+		_, _ = genFile.Write([]byte(graphql.TypeDef(gqlType, params)))
 	}
+	_, _ = genFile.Write([]byte("\n"))
 }
 
 func graphqlFileName(name string) string {
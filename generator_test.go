@@ -33,19 +33,57 @@ func itGeneratesTheCorrectOutput(t *testing.T, name, parameter string) {
 	}
 
 	for _, proto := range protoFiles {
-		graphql, err := ioutil.ReadFile(strings.TrimSuffix(proto, ".proto") + "_pb.graphql")
-		if err != nil {
-			t.Error(err)
-		}
-
-		expected, err := ioutil.ReadFile(strings.TrimSuffix(proto, ".proto") + ".golden")
-		if err != nil {
-			t.Error(err)
-		}
-
-		if string(graphql) != string(expected) {
-			t.Errorf("expected %s to equal %s", graphql, expected)
-		}
+		base := strings.TrimSuffix(proto, ".proto")
+		itMatchesTheGoldenFile(t, base+"_pb.graphql", base+".golden")
+	}
+}
+
+func itMatchesTheGoldenFile(t *testing.T, generatedFile, goldenFile string) {
+	generated, err := ioutil.ReadFile(generatedFile)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if string(generated) != string(expected) {
+		t.Errorf("expected %s to equal %s", generated, expected)
+	}
+}
+
+// itReportsTheDiagnostics runs the plugin on a test case with errors, and
+// checks that it fails with the diagnostics in its errors.golden file.
+func itReportsTheDiagnostics(t *testing.T, name, parameter string) {
+	protoFiles, err := filepath.Glob(filepath.Join("testdata", name, "*.proto"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	args := append([]string{
+		"-I", "testdata",
+		"-I", "protobuf",
+		"--plugin=bin/protoc-gen-graphql",
+		fmt.Sprintf("--graphql_out=%s:testdata", parameter),
+	}, protoFiles...)
+	stderr := &strings.Builder{}
+	cmd := exec.Command("protoc", args...)
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err == nil {
+		t.Errorf("expected protoc to fail")
+	}
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", name, "errors.golden"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	// The Protobuf compiler prefixes plugin errors with a message that
+	// depends on its version.
+	if !strings.Contains(stderr.String(), string(expected)) {
+		t.Errorf("expected %s to contain %s", stderr.String(), expected)
 	}
 }
 
@@ -65,6 +103,150 @@ func TestWrappersParameter(t *testing.T) {
 	itGeneratesTheCorrectOutput(t, "wrappers", "null_wrappers,input_mode=all")
 }
 
+func TestScalarParameter(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "scalars", "scalar=int32:Int,scalar=uint32:Int,scalar=sint32:Int,scalar=bytes:Base64,scalar=int64:Long,scalar=uint64:Long,null_wrappers")
+}
+
+func TestCustomScalarDefinitions(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "custom_scalars", "timestamp=DateTime,struct=JSON,scalar=int64:Long,"+
+		"scalars_file=custom_scalars/scalars_pb.graphql,specified_by=DateTime:https://scalars.graphql.org/andimarek/date-time")
+	itMatchesTheGoldenFile(t, "testdata/custom_scalars/scalars_pb.graphql", "testdata/custom_scalars/scalars.golden")
+}
+
+func itGeneratesTheCorrectLayout(t *testing.T, parameter string, goldenFiles map[string]string) {
+	protoFiles, err := filepath.Glob(filepath.Join("testdata", "layout", "*.proto"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err := runProtoc(protoFiles, parameter); err != nil {
+		t.Error(err)
+	}
+
+	for generated, golden := range goldenFiles {
+		itMatchesTheGoldenFile(t, filepath.Join("testdata", "layout", generated), filepath.Join("testdata", "layout", golden))
+	}
+}
+
+func TestSingleLayout(t *testing.T) {
+	itGeneratesTheCorrectLayout(t, "layout=single=layout/schema.graphql", map[string]string{
+		"schema.graphql": "single.golden",
+	})
+}
+
+func TestPerPackageLayout(t *testing.T) {
+	itGeneratesTheCorrectLayout(t, "layout=per_package", map[string]string{
+		"protoc_gen_graphql.test.layout_pb.graphql": "per_package.golden",
+	})
+}
+
+func TestPerServiceLayout(t *testing.T) {
+	itGeneratesTheCorrectLayout(t, "layout=per_service", map[string]string{
+		"users_Admin_pb.graphql": "per_service_users_Admin.golden",
+		"posts_Posts_pb.graphql": "per_service_posts_Posts.golden",
+		"users_Users_pb.graphql": "per_service_users_Users.golden",
+		"posts_pb.graphql":       "per_service_posts.golden",
+		"users_pb.graphql":       "per_service_users.golden",
+	})
+}
+
+func TestResolverManifest(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "manifest", "manifest=manifest/manifest.json,root_type_prefix=")
+	itMatchesTheGoldenFile(t, "testdata/manifest/manifest.json", "testdata/manifest/manifest.golden")
+}
+
+func TestFederation(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "federation", "federation=2,root_type_prefix=")
+}
+
+func TestConnections(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "connections", "manifest=connections/manifest.json")
+	itMatchesTheGoldenFile(t, "testdata/connections/manifest.json", "testdata/connections/manifest.golden")
+}
+
+func TestOneofDirective(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "oneof_directive", "oneof_directive")
+}
+
+func TestOneofStyleUnion(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "oneof_union", "oneof_style=union")
+}
+
+func TestOneofStyleInterface(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "oneof_interface", "oneof_style=interface")
+}
+
+func TestOneofStyleFlatten(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "oneof_flatten", "oneof_style=flatten")
+}
+
+func TestMapStyleList(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "map_style_list", "map_style=list")
+}
+
+func TestMapStyleJSON(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "map_style_json", "map_style=json")
+}
+
+func TestEnumValues(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "enum_values", "enum_trim_prefix,enum_unspecified=drop")
+}
+
+func TestInputDefaults(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "defaults", "input_mode=all,js_64bit_type=string,enum_trim_prefix")
+}
+
+func TestInputZeroDefaults(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "zero_defaults", "input_mode=all,zero_defaults")
+}
+
+func TestFieldBehavior(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "field_behavior", "")
+}
+
+func TestInferOperationsFromHTTPRules(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "infer_operations_http", "infer_operations=http")
+}
+
+func TestInferOperationsFromAIPMethods(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "infer_operations_aip", "infer_operations=aip,loaders=go,paths=source_relative")
+	itMatchesTheGoldenFile(t, "testdata/infer_operations_aip/service_loaders.pb.go", "testdata/infer_operations_aip/service_loaders.golden")
+}
+
+func TestFlattenArguments(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "flatten_arguments", "zero_defaults")
+}
+
+func TestResponsePath(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "response_path", "")
+}
+
+func TestDiagnostics(t *testing.T) {
+	itReportsTheDiagnostics(t, "diagnostics", "")
+}
+
+func TestSchemaValidation(t *testing.T) {
+	itReportsTheDiagnostics(t, "validation", "")
+}
+
+func TestTypeNameCollisions(t *testing.T) {
+	itReportsTheDiagnostics(t, "type_name_collisions", "")
+	itGeneratesTheCorrectOutput(t, "type_name_collisions", "type_name_collisions=disambiguate")
+	itReportsTheDiagnostics(t, "federation_collisions", "federation=2")
+}
+
+func TestTypeNameTemplates(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "type_name_templates", "object_name_template={namespace}{name},input_name_template={namespace}{path}{suffix},enum_name_template={path},oneof_name_template={name}{oneof},oneof_member_name_template={name}{oneof}{field},map_entry_name_template={name}{field},input_suffix=Params,type_name_rewrite=RequestParams$:Args,type_name_rewrite=_:")
+}
+
+func TestFieldNameJSON(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "field_name_json", "field_name=json,enum_value_name=lower_camel_case,enum_trim_prefix,map_style=list")
+}
+
+func TestFieldNameSnakeCase(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "field_name_snake_case", "field_name=snake_case,method_name=upper_camel_case,enum_value_name=upper_camel_case")
+}
+
 func TestProtobufExtensions(t *testing.T) {
 	itGeneratesTheCorrectOutput(t, "extensions", "root_type_prefix,input_mode=all")
 }
@@ -92,3 +274,20 @@ func TestEmptyProto(t *testing.T) {
 func TestNullableListTypes(t *testing.T) {
 	itGeneratesTheCorrectOutput(t, "nullable_list_types", "nullable_list_types")
 }
+
+func TestGoLoaders(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "loaders", "loaders=go,paths=source_relative")
+	itMatchesTheGoldenFile(t, "testdata/loaders/service_loaders.pb.go", "testdata/loaders/service_loaders.golden")
+}
+
+func TestStreamingMethods(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "streaming", "")
+}
+
+func TestProto3Optional(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "proto3_optional", "")
+}
+
+func TestEditions(t *testing.T) {
+	itGeneratesTheCorrectOutput(t, "editions", "")
+}
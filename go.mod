@@ -3,6 +3,6 @@ module github.com/martinxsliu/protoc-gen-graphql
 go 1.15
 
 require (
-	github.com/golang/protobuf v1.4.1
-	google.golang.org/protobuf v1.25.0
+	github.com/golang/protobuf v1.5.4
+	google.golang.org/protobuf v1.34.2
 )
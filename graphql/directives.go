@@ -0,0 +1,11 @@
+package graphql
+
+const DirectiveOneOf = "oneOf"
+
+// OneOfDirective is the definition of the @oneOf directive from the OneOf
+// Input Objects RFC.
+var OneOfDirective = &Directive{
+	Name:        DirectiveOneOf,
+	Description: "Indicates that exactly one field of an input object must be set and non-null.",
+	Locations:   []string{"INPUT_OBJECT"},
+}
@@ -13,6 +13,9 @@ const (
 	KindInput
 	KindEnum
 	KindUnion
+	KindInterface
+	KindSchema
+	KindDirective
 )
 
 type TypeModifier uint32
@@ -29,6 +32,9 @@ const (
 type Scalar struct {
 	Name        string
 	Description string
+	// URL of the specification of the scalar, output with the @specifiedBy
+	// directive if set.
+	SpecifiedByURL string
 }
 
 func (g *Scalar) Kind() Kind       { return KindScalar }
@@ -38,13 +44,26 @@ func (g *Scalar) String() string   { return g.Name }
 type Object struct {
 	Name        string
 	Description string
-	Fields      []*Field
+	// Names of the interfaces that the object implements.
+	Interfaces []string
+	Fields     []*Field
+	Directives []string
 }
 
 func (g *Object) Kind() Kind       { return KindObject }
 func (g *Object) TypeName() string { return g.Name }
 func (g *Object) String() string   { return g.Name }
 
+type Interface struct {
+	Name        string
+	Description string
+	Fields      []*Field
+}
+
+func (g *Interface) Kind() Kind       { return KindInterface }
+func (g *Interface) TypeName() string { return g.Name }
+func (g *Interface) String() string   { return g.Name }
+
 type ExtendObject struct {
 	Name   string
 	Fields []*Field
@@ -54,10 +73,20 @@ func (g *ExtendObject) Kind() Kind       { return KindObject }
 func (g *ExtendObject) TypeName() string { return g.Name }
 func (g *ExtendObject) String() string   { return g.Name }
 
+// ExtendSchema is a schema extension, used to add directives to the schema.
+type ExtendSchema struct {
+	Directives []string
+}
+
+func (g *ExtendSchema) Kind() Kind       { return KindSchema }
+func (g *ExtendSchema) TypeName() string { return "" }
+func (g *ExtendSchema) String() string   { return "schema" }
+
 type Input struct {
 	Name        string
 	Description string
 	Fields      []*Field
+	Directives  []string
 }
 
 func (g *Input) Kind() Kind       { return KindInput }
@@ -71,6 +100,8 @@ type Field struct {
 	Arguments   []*Argument
 	Modifiers   TypeModifier
 	Directives  []string
+	// Default value of an input field, in GraphQL syntax.
+	Default string
 }
 
 type Argument struct {
@@ -79,8 +110,20 @@ type Argument struct {
 	TypeName    string
 	Default     string
 	Modifiers   TypeModifier
+	Directives  []string
+}
+
+// Directive is a directive definition.
+type Directive struct {
+	Name        string
+	Description string
+	Locations   []string
 }
 
+func (g *Directive) Kind() Kind       { return KindDirective }
+func (g *Directive) TypeName() string { return g.Name }
+func (g *Directive) String() string   { return "@" + g.Name }
+
 type Enum struct {
 	Name        string
 	Description string
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+	"strconv"
 	"strings"
 )
 
@@ -21,13 +22,34 @@ func TypeDef(graphqlType Type, params *parameters.Parameters) string {
 		return typeDefEnum(graphqlType)
 	case *Union:
 		return typeDefUnion(graphqlType)
+	case *Interface:
+		return typeDefInterface(graphqlType, params.NullableListTypes)
+	case *ExtendSchema:
+		return typeDefExtendSchema(graphqlType)
+	case *Directive:
+		return typeDefDirective(graphqlType)
 	default:
 		return ""
 	}
 }
 
 func typeDefScalar(scalar *Scalar) string {
-	return scalar.Name
+	b := &strings.Builder{}
+
+	if scalar.Description != "" {
+		writeDescription(b, scalar.Description, 0)
+	}
+
+	b.WriteString("scalar ")
+	b.WriteString(scalar.Name)
+
+	if scalar.SpecifiedByURL != "" {
+		b.WriteString(" @specifiedBy(url: ")
+		b.WriteString(strconv.Quote(scalar.SpecifiedByURL))
+		b.WriteString(")")
+	}
+
+	return b.String()
 }
 
 func typeDefObject(object *Object, nullableListTypes bool) string {
@@ -40,6 +62,16 @@ func typeDefObject(object *Object, nullableListTypes bool) string {
 	b.WriteString("type ")
 	b.WriteString(object.Name)
 
+	if len(object.Interfaces) > 0 {
+		b.WriteString(" implements ")
+		b.WriteString(strings.Join(object.Interfaces, " & "))
+	}
+
+	for _, directive := range object.Directives {
+		b.WriteString(" @")
+		b.WriteString(directive)
+	}
+
 	// Omit braces if we don't have any fields, e.g. `type Empty`.
 	if len(object.Fields) > 0 {
 		b.WriteString(" {\n")
@@ -73,7 +105,27 @@ func typeDefField(b *strings.Builder, field *Field, nullableListTypes bool) {
 	b.WriteString("  ")
 	b.WriteString(field.Name)
 
-	if len(field.Arguments) != 0 {
+	// Arguments are output on separate lines if any of them have a
+	// description.
+	var multiline bool
+	for _, arg := range field.Arguments {
+		if arg.Description != "" {
+			multiline = true
+		}
+	}
+
+	if len(field.Arguments) != 0 && multiline {
+		b.WriteString("(\n")
+		for _, arg := range field.Arguments {
+			if arg.Description != "" {
+				writeDescription(b, arg.Description, 4)
+			}
+			b.WriteString("    ")
+			typeDefArgument(b, arg)
+			b.WriteString("\n")
+		}
+		b.WriteString("  )")
+	} else if len(field.Arguments) != 0 {
 		b.WriteString("(")
 		for i, arg := range field.Arguments {
 			if i != 0 {
@@ -87,6 +139,11 @@ func typeDefField(b *strings.Builder, field *Field, nullableListTypes bool) {
 	b.WriteString(": ")
 	b.WriteString(typeName)
 
+	if field.Default != "" {
+		b.WriteString(" = ")
+		b.WriteString(field.Default)
+	}
+
 	for _, directive := range field.Directives {
 		b.WriteString(" @")
 		b.WriteString(directive)
@@ -98,6 +155,12 @@ func typeDefArgument(b *strings.Builder, argument *Argument) string {
 	if argument.Modifiers&TypeModifierNonNull > 0 {
 		typeName = typeName + "!"
 	}
+	if argument.Modifiers&TypeModifierList > 0 {
+		typeName = "[" + typeName + "]"
+		if argument.Modifiers&TypeModifierNonNullList > 0 {
+			typeName = typeName + "!"
+		}
+	}
 
 	b.WriteString(argument.Name)
 	b.WriteString(": ")
@@ -108,6 +171,33 @@ func typeDefArgument(b *strings.Builder, argument *Argument) string {
 		b.WriteString(argument.Default)
 	}
 
+	for _, directive := range argument.Directives {
+		b.WriteString(" @")
+		b.WriteString(directive)
+	}
+
+	return b.String()
+}
+
+func typeDefInterface(iface *Interface, nullableListTypes bool) string {
+	b := &strings.Builder{}
+
+	if iface.Description != "" {
+		writeDescription(b, iface.Description, 0)
+	}
+
+	b.WriteString("interface ")
+	b.WriteString(iface.Name)
+
+	if len(iface.Fields) > 0 {
+		b.WriteString(" {\n")
+		for _, field := range iface.Fields {
+			typeDefField(b, field, nullableListTypes)
+			b.WriteString("\n")
+		}
+		b.WriteString("}")
+	}
+
 	return b.String()
 }
 
@@ -139,6 +229,11 @@ func typeDefInput(input *Input, nullableListTypes bool) string {
 	b.WriteString("input ")
 	b.WriteString(input.Name)
 
+	for _, directive := range input.Directives {
+		b.WriteString(" @")
+		b.WriteString(directive)
+	}
+
 	// Omit braces if we don't have any fields, e.g. `input Empty`.
 	if len(input.Fields) > 0 {
 		b.WriteString(" {\n")
@@ -202,6 +297,31 @@ func typeDefUnion(union *Union) string {
 	return b.String()
 }
 
+func typeDefExtendSchema(schema *ExtendSchema) string {
+	b := &strings.Builder{}
+	b.WriteString("extend schema")
+	for _, directive := range schema.Directives {
+		b.WriteString("\n  @")
+		b.WriteString(directive)
+	}
+	return b.String()
+}
+
+func typeDefDirective(directive *Directive) string {
+	b := &strings.Builder{}
+
+	if directive.Description != "" {
+		writeDescription(b, directive.Description, 0)
+	}
+
+	b.WriteString("directive @")
+	b.WriteString(directive.Name)
+	b.WriteString(" on ")
+	b.WriteString(strings.Join(directive.Locations, " | "))
+
+	return b.String()
+}
+
 func writeDescription(b *strings.Builder, description string, indent int) {
 	lines := strings.Split(description, "\n")
 	prefix := strings.Repeat(" ", indent)
@@ -0,0 +1,227 @@
+package graphql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var nameRegexp = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+var builtinScalars = map[string]*Scalar{
+	ScalarInt.Name:     ScalarInt,
+	ScalarFloat.Name:   ScalarFloat,
+	ScalarString.Name:  ScalarString,
+	ScalarBoolean.Name: ScalarBoolean,
+	ScalarID.Name:      ScalarID,
+}
+
+// ValidationError is a problem with a GraphQL type. Field, Argument and
+// EnumValue are set if the problem is with one of them.
+type ValidationError struct {
+	Type      Type
+	Field     *Field
+	Argument  *Argument
+	EnumValue *EnumValue
+	Message   string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Validate validates types against the rules of the GraphQL type system:
+//
+//   - Names are valid GraphQL names, and are not reserved.
+//   - Type names referenced by fields, arguments, unions and interfaces are
+//     defined, either by definitions or as built-in scalars.
+//   - Input fields and arguments reference input types, enums or scalars, and
+//     object and interface fields do not reference input types.
+//   - Objects, interfaces, inputs, enums and unions are not empty.
+//   - Names of types, fields, arguments, enum values and union members are
+//     unique.
+//
+// Extensions of types that are not in definitions, such as the root Query
+// type, are allowed.
+func Validate(types []Type, definitions map[string]Type) []*ValidationError {
+	v := &validator{definitions: definitions}
+	names := make(map[string]bool)
+	for _, t := range types {
+		v.validateType(t)
+
+		if _, ok := t.(*ExtendObject); ok {
+			continue
+		}
+		if names[t.TypeName()] {
+			v.errorf(ValidationError{Type: t}, "type %s is defined more than once", t.TypeName())
+		}
+		names[t.TypeName()] = true
+	}
+	return v.errors
+}
+
+type validator struct {
+	definitions map[string]Type
+	errors      []*ValidationError
+}
+
+// errorf records an error with the type, field, argument or enum value of
+// err.
+func (v *validator) errorf(err ValidationError, format string, args ...interface{}) {
+	err.Message = fmt.Sprintf(format, args...)
+	v.errors = append(v.errors, &err)
+}
+
+func (v *validator) validateType(t Type) {
+	switch t := t.(type) {
+	case *Object:
+		v.validateName(t, "type", t.Name)
+		for _, name := range t.Interfaces {
+			if iface, ok := v.definitions[name]; !ok || iface.Kind() != KindInterface {
+				v.errorf(ValidationError{Type: t}, "type %s implements %s, which is not an interface", t.Name, name)
+			}
+		}
+		v.validateFields(t, t.Fields, false)
+	case *ExtendObject:
+		v.validateName(t, "type", t.Name)
+		v.validateFields(t, t.Fields, false)
+	case *Interface:
+		v.validateName(t, "interface", t.Name)
+		v.validateFields(t, t.Fields, false)
+	case *Input:
+		v.validateName(t, "input", t.Name)
+		v.validateFields(t, t.Fields, true)
+	case *Enum:
+		v.validateName(t, "enum", t.Name)
+		v.validateEnumValues(t)
+	case *Union:
+		v.validateName(t, "union", t.Name)
+		v.validateUnionMembers(t)
+	case *Scalar:
+		v.validateName(t, "scalar", t.Name)
+	}
+}
+
+func (v *validator) validateName(t Type, kind, name string) {
+	if !nameRegexp.MatchString(name) {
+		v.errorf(ValidationError{Type: t}, "%s name %q is not a valid GraphQL name", kind, name)
+	} else if strings.HasPrefix(name, "__") {
+		v.errorf(ValidationError{Type: t}, "%s name %s is reserved, names must not start with \"__\"", kind, name)
+	}
+}
+
+func (v *validator) validateFields(t Type, fields []*Field, input bool) {
+	if len(fields) == 0 {
+		switch t.(type) {
+		case *Object:
+			v.errorf(ValidationError{Type: t}, "type %s has no fields", t.TypeName())
+		case *Interface:
+			v.errorf(ValidationError{Type: t}, "interface %s has no fields", t.TypeName())
+		case *Input:
+			v.errorf(ValidationError{Type: t}, "input %s has no fields", t.TypeName())
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, field := range fields {
+		err := ValidationError{Type: t, Field: field}
+		if !nameRegexp.MatchString(field.Name) {
+			v.errorf(err, "field name %q of %s is not a valid GraphQL name", field.Name, t.TypeName())
+		} else if strings.HasPrefix(field.Name, "__") {
+			v.errorf(err, "field name %s of %s is reserved, names must not start with \"__\"", field.Name, t.TypeName())
+		}
+		if names[field.Name] {
+			v.errorf(err, "field %s of %s is defined more than once", field.Name, t.TypeName())
+		}
+		names[field.Name] = true
+
+		v.validateTypeReference(err, fmt.Sprintf("field %s of %s", field.Name, t.TypeName()), field.TypeName, input)
+
+		argumentNames := make(map[string]bool)
+		for _, argument := range field.Arguments {
+			err := ValidationError{Type: t, Field: field, Argument: argument}
+			description := fmt.Sprintf("argument %s of %s.%s", argument.Name, t.TypeName(), field.Name)
+			if !nameRegexp.MatchString(argument.Name) {
+				v.errorf(err, "%s is not a valid GraphQL name", description)
+			} else if strings.HasPrefix(argument.Name, "__") {
+				v.errorf(err, "%s is reserved, names must not start with \"__\"", description)
+			}
+			if argumentNames[argument.Name] {
+				v.errorf(err, "%s is defined more than once", description)
+			}
+			argumentNames[argument.Name] = true
+
+			v.validateTypeReference(err, description, argument.TypeName, true)
+		}
+	}
+}
+
+// validateTypeReference validates that a type name, which may include list
+// and non-null modifiers, references a defined type of a kind that can be
+// used for inputs or outputs.
+func (v *validator) validateTypeReference(err ValidationError, description, typeName string, input bool) {
+	name := strings.Trim(typeName, "[]!")
+	if name == "" {
+		v.errorf(err, "%s has no type", description)
+		return
+	}
+
+	var t Type
+	if scalar, ok := builtinScalars[name]; ok {
+		t = scalar
+	} else if t, ok = v.definitions[name]; !ok {
+		v.errorf(err, "%s references undefined type %s", description, name)
+		return
+	}
+
+	switch t.Kind() {
+	case KindScalar, KindEnum:
+	case KindInput:
+		if !input {
+			v.errorf(err, "%s references input type %s, but only object, interface, union, enum and scalar types can be output", description, name)
+		}
+	default:
+		if input {
+			v.errorf(err, "%s references output type %s, but only input, enum and scalar types can be input", description, name)
+		}
+	}
+}
+
+func (v *validator) validateEnumValues(enum *Enum) {
+	if len(enum.Values) == 0 {
+		v.errorf(ValidationError{Type: enum}, "enum %s has no values", enum.Name)
+	}
+
+	names := make(map[string]bool)
+	for _, value := range enum.Values {
+		err := ValidationError{Type: enum, EnumValue: value}
+		switch {
+		case !nameRegexp.MatchString(value.Name):
+			v.errorf(err, "enum value %q of %s is not a valid GraphQL name", value.Name, enum.Name)
+		case value.Name == "true" || value.Name == "false" || value.Name == "null":
+			v.errorf(err, "enum value %s of %s is reserved", value.Name, enum.Name)
+		case names[value.Name]:
+			v.errorf(err, "enum value %s of %s is defined more than once", value.Name, enum.Name)
+		}
+		names[value.Name] = true
+	}
+}
+
+func (v *validator) validateUnionMembers(union *Union) {
+	if len(union.TypeNames) == 0 {
+		v.errorf(ValidationError{Type: union}, "union %s has no member types", union.Name)
+	}
+
+	names := make(map[string]bool)
+	for _, name := range union.TypeNames {
+		if member, ok := v.definitions[name]; !ok {
+			v.errorf(ValidationError{Type: union}, "union %s references undefined type %s", union.Name, name)
+		} else if _, ok := member.(*Object); !ok {
+			v.errorf(ValidationError{Type: union}, "member %s of union %s is not an object type", name, union.Name)
+		}
+		if names[name] {
+			v.errorf(ValidationError{Type: union}, "member %s of union %s is listed more than once", name, union.Name)
+		}
+		names[name] = true
+	}
+}
@@ -0,0 +1,38 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	pageInfo := &Object{Name: "PageInfo", Fields: []*Field{{Name: "hasNextPage", TypeName: "Boolean"}}}
+	otherPageInfo := &Object{Name: "PageInfo", Fields: []*Field{{Name: "id", TypeName: "String"}}}
+	empty := &Input{Name: "EmptyInput"}
+	extension := &ExtendObject{Name: "PageInfo", Fields: []*Field{{Name: "total", TypeName: "Int"}}}
+	query := &ExtendObject{Name: "Query", Fields: []*Field{{
+		Name:      "pageInfo",
+		TypeName:  "PageInfo",
+		Arguments: []*Argument{{Name: "__id", TypeName: "String"}},
+	}}}
+
+	types := []Type{pageInfo, otherPageInfo, empty, extension, query}
+	definitions := map[string]Type{"PageInfo": pageInfo, "EmptyInput": empty}
+
+	var messages []string
+	for _, err := range Validate(types, definitions) {
+		messages = append(messages, err.Message)
+	}
+	expected := []string{
+		"type PageInfo is defined more than once",
+		"input EmptyInput has no fields",
+		"argument __id of Query.pageInfo is reserved, names must not start with \"__\"",
+	}
+	if len(messages) != len(expected) {
+		t.Fatalf("got %q; want %q", messages, expected)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Errorf("got %q; want %q", messages[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+	"github.com/martinxsliu/protoc-gen-graphql/mapper"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+// typeGroup is a group of GraphQL types that are output to the same file.
+type typeGroup struct {
+	fileName string
+	types    []graphql.Type
+	// Whether to skip the file if it has no types.
+	omitEmpty bool
+}
+
+// outputGroups returns the groups of types to output. Definitions that are
+// shared by the whole schema, such as the federation types and directive
+// definitions, are output in the first group. Types that are shared by
+// multiple groups are only output in the first group that contains them, and
+// groups that are left without types are omitted if they are optional.
+func (g *Generator) outputGroups(params *parameters.Parameters) []*typeGroup {
+	groups := g.groupTypes(params)
+	if params.OneofDirective && len(groups) > 0 && usesOneOfDirective(groups) {
+		groups[0].types = append([]graphql.Type{graphql.OneOfDirective}, groups[0].types...)
+	}
+	if params.Federation != parameters.FederationNone && len(groups) > 0 {
+		groups[0].types = append(g.federationTypes(), groups[0].types...)
+	}
+
+	var outputGroups []*typeGroup
+	written := make(map[graphql.Type]bool)
+	for _, group := range groups {
+		var gqlTypes []graphql.Type
+		for _, gqlType := range group.types {
+			if !written[gqlType] {
+				written[gqlType] = true
+				gqlTypes = append(gqlTypes, gqlType)
+			}
+		}
+		if len(gqlTypes) == 0 && group.omitEmpty {
+			continue
+		}
+		outputGroups = append(outputGroups, &typeGroup{fileName: group.fileName, types: gqlTypes})
+	}
+	return outputGroups
+}
+
+func usesOneOfDirective(groups []*typeGroup) bool {
+	for _, group := range groups {
+		for _, gqlType := range group.types {
+			if input, ok := gqlType.(*graphql.Input); ok {
+				for _, directive := range input.Directives {
+					if directive == graphql.DirectiveOneOf {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// groupTypes groups the types generated for the files to generate according
+// to the 'layout' parameter.
+func (g *Generator) groupTypes(params *parameters.Parameters) []*typeGroup {
+	switch params.Layout {
+	case parameters.LayoutPerPackage:
+		return g.groupTypesByPackage()
+	case parameters.LayoutPerService:
+		return g.groupTypesByService()
+	case parameters.LayoutSingle:
+		group := &typeGroup{fileName: params.SingleFileName}
+		for _, fileName := range g.req.GetFileToGenerate() {
+			group.types = append(group.types, g.fileTypes(fileName)...)
+		}
+		return []*typeGroup{group}
+	default:
+		return g.groupTypesByFile()
+	}
+}
+
+func (g *Generator) groupTypesByFile() []*typeGroup {
+	var groups []*typeGroup
+	for _, fileName := range g.req.GetFileToGenerate() {
+		groups = append(groups, &typeGroup{
+			fileName: graphqlFileName(fileName),
+			types:    g.fileTypes(fileName),
+		})
+	}
+	return groups
+}
+
+// groupTypesByPackage outputs the types of each protobuf package to a file
+// named after the package, in the directory of the package's first file.
+func (g *Generator) groupTypesByPackage() []*typeGroup {
+	var groups []*typeGroup
+	packages := make(map[string]*typeGroup)
+	for _, fileName := range g.req.GetFileToGenerate() {
+		pkg := g.mapper.Files[fileName].Proto.GetPackage()
+		group, ok := packages[pkg]
+		if !ok {
+			name := pkg
+			if name == "" {
+				name = "schema"
+			}
+			group = &typeGroup{fileName: path.Join(path.Dir(fileName), name+"_pb.graphql")}
+			packages[pkg] = group
+			groups = append(groups, group)
+		}
+		group.types = append(group.types, g.fileTypes(fileName)...)
+	}
+	return groups
+}
+
+// groupTypesByService outputs the types that are only reachable from the root
+// fields of a single gRPC service to a file named after the service's file and
+// the service. Types that are reachable from multiple
+// services, or from none, are output to the file they would be in with the
+// per_file layout.
+func (g *Generator) groupTypesByService() []*typeGroup {
+	types := make(map[string]graphql.Type)
+	for _, fileName := range g.req.GetFileToGenerate() {
+		for _, gqlType := range g.fileTypes(fileName) {
+			// Root types extended by multiple services share the same name, so
+			// they are never looked up by name.
+			if _, ok := gqlType.(*graphql.ExtendObject); !ok {
+				types[gqlType.TypeName()] = gqlType
+			}
+		}
+	}
+
+	var groups []*typeGroup
+	reachedBy := make(map[graphql.Type]int)
+	for _, fileName := range g.req.GetFileToGenerate() {
+		for _, service := range g.mapper.Files[fileName].Services {
+			m, ok := g.mapper.ServiceMappers[service.FullName]
+			if !ok {
+				continue // Service was skipped
+			}
+
+			var roots []graphql.Type
+			for _, methods := range []*mapper.MethodsMapper{m.Queries, m.Mutations, m.Subscriptions} {
+				if methods == nil {
+					continue
+				}
+				if methods.ExtendRootObject != nil {
+					roots = append(roots, methods.ExtendRootObject)
+				}
+				roots = append(roots, methods.Object)
+			}
+
+			reachable := reachableTypes(roots, types)
+			for _, gqlType := range reachable {
+				reachedBy[gqlType]++
+			}
+			groups = append(groups, &typeGroup{
+				fileName: strings.TrimSuffix(fileName, ".proto") + "_" + service.Proto.GetName() + "_pb.graphql",
+				types:    reachable,
+			})
+		}
+	}
+
+	for _, group := range groups {
+		var unshared []graphql.Type
+		for _, gqlType := range group.types {
+			if reachedBy[gqlType] == 1 {
+				unshared = append(unshared, gqlType)
+			}
+		}
+		group.types = unshared
+	}
+
+	for _, group := range g.groupTypesByFile() {
+		group.omitEmpty = true
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// reachableTypes returns the roots followed by the types that are transitively
+// referenced by them, including the objects that implement referenced
+// interfaces, in the order that they are first referenced. Type names
+// that are not in types, e.g. scalars, are ignored.
+func reachableTypes(roots []graphql.Type, types map[string]graphql.Type) []graphql.Type {
+	// Objects that implement an interface are reachable from it.
+	implementers := make(map[string][]string)
+	for _, gqlType := range types {
+		if object, ok := gqlType.(*graphql.Object); ok {
+			for _, iface := range object.Interfaces {
+				implementers[iface] = append(implementers[iface], object.Name)
+			}
+		}
+	}
+	for _, names := range implementers {
+		sort.Strings(names)
+	}
+
+	var reachable []graphql.Type
+	visited := make(map[graphql.Type]bool)
+
+	var visit func(gqlType graphql.Type)
+	visit = func(gqlType graphql.Type) {
+		if visited[gqlType] {
+			return
+		}
+		visited[gqlType] = true
+		reachable = append(reachable, gqlType)
+
+		typeNames := referencedTypeNames(gqlType)
+		switch gqlType := gqlType.(type) {
+		case *graphql.Union:
+			typeNames = gqlType.TypeNames
+		case *graphql.Interface:
+			typeNames = append(typeNames, implementers[gqlType.Name]...)
+		}
+		for _, typeName := range typeNames {
+			if referenced, ok := types[typeName]; ok {
+				visit(referenced)
+			}
+		}
+	}
+
+	for _, root := range roots {
+		visit(root)
+	}
+	return reachable
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+)
+
+const (
+	contextPackage    = protogen.GoImportPath("context")
+	dataloaderPackage = protogen.GoImportPath("github.com/martinxsliu/protoc-gen-graphql/dataloader")
+)
+
+// generateLoaders generates a Go file of typed DataLoaders for each file to
+// generate that declares gRPC methods with the 'load_one' or 'load_many'
+// options. The loaders are generated into the same Go package as the file's
+// gRPC client.
+func (g *Generator) generateLoaders() {
+	messages := make(map[string]*protogen.Message)
+	for _, file := range g.gen.Files {
+		collectGoMessages(messages, file.Messages)
+	}
+
+	for _, file := range g.gen.Files {
+		if !file.Generate {
+			continue
+		}
+
+		var loaders []*descriptor.Loader
+		for _, service := range g.mapper.Files[file.Desc.Path()].Services {
+			for _, method := range service.Methods {
+				loaders = append(loaders, method.Loaders...)
+			}
+		}
+		if len(loaders) == 0 {
+			continue
+		}
+
+		genFile := g.gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_loaders.pb.go", file.GoImportPath)
+		genFile.P("// Code generated by protoc-gen-graphql. DO NOT EDIT.")
+		genFile.P("// source: ", file.Desc.Path())
+		genFile.P()
+		genFile.P("package ", file.GoPackageName)
+
+		for _, loader := range loaders {
+			method, err := findGoMethod(file, loader.Method)
+			if err != nil {
+				g.diagnostics.Errorf(loader.Method.Location, "%s", err.Error())
+				continue
+			}
+			message, ok := messages[loader.FullName]
+			if !ok {
+				g.diagnostics.Errorf(loader.Method.Location, "unknown type for loader: %s", strings.TrimPrefix(loader.FullName, "."))
+				continue
+			}
+			if !g.validateLoader(genFile, loader, method, message) {
+				continue
+			}
+			generateLoader(genFile, loader, method, message)
+		}
+	}
+}
+
+// validateLoader validates that the field paths of a loader exist and have
+// the expected types, and records an error at the loader's method otherwise.
+func (g *Generator) validateLoader(genFile *protogen.GeneratedFile, loader *descriptor.Loader, method *protogen.Method, message *protogen.Message) bool {
+	methodName := fmt.Sprintf("%s.%s", method.Parent.Desc.Name(), method.Desc.Name())
+	location := loader.Method.Location
+
+	keyField, err := resolveGoFieldPath(method.Input, loader.RequestFieldPath)
+	if err != nil {
+		g.diagnostics.Errorf(location, "invalid loader request field path of %s: %s", methodName, err.Error())
+		return false
+	}
+	if keyField.Desc.IsList() != loader.Many {
+		g.diagnostics.Errorf(location, "loader request field %s of %s must be repeated if and only if the loader is a load_many loader",
+			strings.Join(loader.RequestFieldPath, "."), methodName)
+		return false
+	}
+	keyType, ok := goKeyType(genFile, keyField)
+	if !ok {
+		g.diagnostics.Errorf(location, "loader key field %s for %s must be a comparable scalar type, got %s",
+			keyField.Desc.FullName(), methodName, keyField.Desc.Kind())
+		return false
+	}
+
+	// An empty response field path loads the response message itself.
+	if len(loader.ResponseFieldPath) == 0 {
+		if loader.Many || method.Output.Desc.FullName() != message.Desc.FullName() {
+			g.diagnostics.Errorf(location, "loader response of %s must have type %s and the loader must be a load_one loader",
+				methodName, message.Desc.FullName())
+			return false
+		}
+	} else {
+		objectField, err := resolveGoFieldPath(method.Output, loader.ResponseFieldPath)
+		if err != nil {
+			g.diagnostics.Errorf(location, "invalid loader response field path of %s: %s", methodName, err.Error())
+			return false
+		}
+		if objectField.Message == nil || objectField.Message.Desc.FullName() != message.Desc.FullName() {
+			g.diagnostics.Errorf(location, "loader response field %s of %s must have type %s",
+				strings.Join(loader.ResponseFieldPath, "."), methodName, message.Desc.FullName())
+			return false
+		}
+		if objectField.Desc.IsList() != loader.Many {
+			g.diagnostics.Errorf(location, "loader response field %s of %s must be repeated if and only if the loader is a load_many loader",
+				strings.Join(loader.ResponseFieldPath, "."), methodName)
+			return false
+		}
+	}
+
+	if loader.Many {
+		objectKeyField, err := resolveGoFieldPath(message, loader.ObjectKeyFieldPath)
+		if err != nil {
+			g.diagnostics.Errorf(location, "invalid loader object key field path of %s: %s", methodName, err.Error())
+			return false
+		}
+		if objectKeyType, _ := goKeyType(genFile, objectKeyField); objectKeyField.Desc.IsList() || objectKeyType != keyType {
+			g.diagnostics.Errorf(location, "loader object key field %s of %s must be a %s",
+				strings.Join(loader.ObjectKeyFieldPath, "."), message.Desc.FullName(), keyType)
+			return false
+		}
+	}
+	return true
+}
+
+// generateLoader generates a typed DataLoader. The loader must be valid.
+func generateLoader(g *protogen.GeneratedFile, loader *descriptor.Loader, method *protogen.Method, message *protogen.Message) {
+	loaderName := message.GoIdent.GoName + "Loader"
+	methodName := fmt.Sprintf("%s.%s", method.Parent.Desc.Name(), method.Desc.Name())
+	clientName := method.Parent.GoName + "Client"
+
+	keyField, _ := resolveGoFieldPath(method.Input, loader.RequestFieldPath)
+	keyType, _ := goKeyType(g, keyField)
+
+	messageType := "*" + g.QualifiedGoIdent(message.GoIdent)
+	ctxType := g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	newLoader := g.QualifiedGoIdent(dataloaderPackage.Ident("New"))
+	optionType := g.QualifiedGoIdent(dataloaderPackage.Ident("Option"))
+	resultType := "*" + g.QualifiedGoIdent(dataloaderPackage.Ident("Result"))
+
+	g.P()
+	g.P("// ", loaderName, " loads ", message.Desc.FullName(), " messages by key using the")
+	g.P("// ", methodName, " method.")
+	g.P("type ", loaderName, " struct {")
+	g.P("loader *", g.QualifiedGoIdent(dataloaderPackage.Ident("Loader")))
+	g.P("}")
+	g.P()
+	g.P("// New", loaderName, " returns a ", loaderName, " that batches keys into calls to")
+	g.P("// the ", methodName, " method.")
+	g.P("func New", loaderName, "(client ", clientName, ", opts ...", optionType, ") *", loaderName, " {")
+	g.P("batchFn := func(ctx ", ctxType, ", keys []interface{}) []", resultType, " {")
+	if loader.Many {
+		g.P("requestKeys := make([]", keyType, ", len(keys))")
+		g.P("for i, key := range keys {")
+		g.P("requestKeys[i] = key.(", keyType, ")")
+		g.P("}")
+		generateLoaderRequest(g, method.Input, loader.RequestFieldPath, "requestKeys")
+		g.P("resp, err := client.", method.GoName, "(ctx, req)")
+		g.P("if err != nil {")
+		g.P("return ", g.QualifiedGoIdent(dataloaderPackage.Ident("ErrorResults")), "(len(keys), err)")
+		g.P("}")
+		g.P()
+		g.P("// Order the loaded messages to correspond with the input keys.")
+		g.P("byKey := make(map[", keyType, "]", messageType, ")")
+		g.P("for _, item := range resp", goGetterChain(loader.ResponseFieldPath, method.Output), " {")
+		g.P("byKey[item", goGetterChain(loader.ObjectKeyFieldPath, message), "] = item")
+		g.P("}")
+		g.P("results := make([]", resultType, ", len(keys))")
+		g.P("for i, key := range keys {")
+		g.P("results[i] = &", g.QualifiedGoIdent(dataloaderPackage.Ident("Result")), "{Value: byKey[key.(", keyType, ")]}")
+		g.P("}")
+		g.P("return results")
+	} else {
+		g.P("return ", g.QualifiedGoIdent(dataloaderPackage.Ident("ForEach")), "(keys, func(key interface{}) (interface{}, error) {")
+		generateLoaderRequest(g, method.Input, loader.RequestFieldPath, "key.("+keyType+")")
+		g.P("resp, err := client.", method.GoName, "(ctx, req)")
+		g.P("if err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return resp", goGetterChain(loader.ResponseFieldPath, method.Output), ", nil")
+		g.P("})")
+	}
+	g.P("}")
+	g.P("return &", loaderName, "{loader: ", newLoader, "(batchFn, opts...)}")
+	g.P("}")
+	g.P()
+	g.P("// Load returns the message for key. The returned message is nil if it")
+	g.P("// was not found.")
+	g.P("func (l *", loaderName, ") Load(ctx ", ctxType, ", key ", keyType, ") (", messageType, ", error) {")
+	g.P("value, err := l.loader.Load(ctx, key)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("item, _ := value.(", messageType, ")")
+	g.P("return item, nil")
+	g.P("}")
+	g.P()
+	g.P("// LoadMany returns the messages for keys, in the same order as keys.")
+	g.P("func (l *", loaderName, ") LoadMany(ctx ", ctxType, ", keys []", keyType, ") ([]", messageType, ", []error) {")
+	g.P("loaderKeys := make([]interface{}, len(keys))")
+	g.P("for i, key := range keys {")
+	g.P("loaderKeys[i] = key")
+	g.P("}")
+	g.P("values, errs := l.loader.LoadMany(ctx, loaderKeys)")
+	g.P("items := make([]", messageType, ", len(values))")
+	g.P("for i, value := range values {")
+	g.P("items[i], _ = value.(", messageType, ")")
+	g.P("}")
+	g.P("return items, errs")
+	g.P("}")
+	g.P()
+	g.P("// Prime adds a message to the cache for key, if key is not already cached.")
+	g.P("func (l *", loaderName, ") Prime(key ", keyType, ", item ", messageType, ") {")
+	g.P("l.loader.Prime(key, item)")
+	g.P("}")
+	g.P()
+	g.P("// Clear removes key from the cache.")
+	g.P("func (l *", loaderName, ") Clear(key ", keyType, ") {")
+	g.P("l.loader.Clear(key)")
+	g.P("}")
+}
+
+// generateLoaderRequest generates code that declares a request message 'req'
+// with the field at path set to value. Intermediate messages along the path,
+// including members of oneofs, are allocated as needed.
+func generateLoaderRequest(g *protogen.GeneratedFile, message *protogen.Message, path []string, value string) {
+	g.P("req := &", g.QualifiedGoIdent(message.GoIdent), "{}")
+
+	parent := "req"
+	for i, name := range path {
+		field := findGoField(message, name)
+
+		fieldValue := value
+		if i != len(path)-1 {
+			fieldValue = fmt.Sprintf("msg%d", i+1)
+			g.P(fieldValue, " := &", g.QualifiedGoIdent(field.Message.GoIdent), "{}")
+		}
+
+		if field.Oneof != nil {
+			g.P(parent, ".", field.Oneof.GoName, " = &", g.QualifiedGoIdent(field.GoIdent), "{", field.GoName, ": ", fieldValue, "}")
+		} else {
+			g.P(parent, ".", field.GoName, " = ", fieldValue)
+		}
+
+		parent = fieldValue
+		message = field.Message
+	}
+}
+
+// goGetterChain returns the chain of getter calls that access the field at
+// path, e.g. ".GetUser().GetId()".
+func goGetterChain(path []string, message *protogen.Message) string {
+	var b strings.Builder
+	for _, name := range path {
+		field := findGoField(message, name)
+		b.WriteString(".Get")
+		b.WriteString(field.GoName)
+		b.WriteString("()")
+		message = field.Message
+	}
+	return b.String()
+}
+
+// goKeyType returns the Go type of a loader key field. Keys are used as map
+// keys, so they must be comparable scalar types. Returns false otherwise.
+func goKeyType(g *protogen.GeneratedFile, field *protogen.Field) (string, bool) {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool", true
+	case protoreflect.StringKind:
+		return "string", true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32", true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32", true
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64", true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64", true
+	case protoreflect.FloatKind:
+		return "float32", true
+	case protoreflect.DoubleKind:
+		return "float64", true
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent), true
+	}
+	return "", false
+}
+
+// resolveGoFieldPath returns the field at the end of path, where each element
+// except the last must be a message field.
+func resolveGoFieldPath(message *protogen.Message, path []string) (*protogen.Field, error) {
+	var field *protogen.Field
+	for i, name := range path {
+		if message == nil {
+			return nil, fmt.Errorf("invalid field path %s: %s is not a message field", strings.Join(path, "."), path[i-1])
+		}
+		field = findGoField(message, name)
+		if field == nil {
+			return nil, fmt.Errorf("unknown field %s in %s", name, message.Desc.FullName())
+		}
+		message = field.Message
+	}
+	return field, nil
+}
+
+// findGoField returns the field of a message with the given name, or nil if
+// there is none.
+func findGoField(message *protogen.Message, name string) *protogen.Field {
+	for _, field := range message.Fields {
+		if string(field.Desc.Name()) == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// findGoMethod returns the Go method of a service method in a file.
+func findGoMethod(file *protogen.File, method *descriptor.Method) (*protogen.Method, error) {
+	for _, service := range file.Services {
+		if string(service.Desc.Name()) != method.Service.Proto.GetName() {
+			continue
+		}
+		for _, m := range service.Methods {
+			if string(m.Desc.Name()) == method.Proto.GetName() {
+				return m, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown method %s.%s", method.Service.Proto.GetName(), method.Proto.GetName())
+}
+
+func collectGoMessages(messages map[string]*protogen.Message, list []*protogen.Message) {
+	for _, message := range list {
+		messages["."+string(message.Desc.FullName())] = message
+		collectGoMessages(messages, message.Messages)
+	}
+}
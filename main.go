@@ -1,12 +1,75 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 func main() {
-	opts := protogen.Options{}
-	opts.Run(func(gen *protogen.Plugin) error {
-		return New(gen).Generate()
-	})
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "protoc-gen-graphql: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run is equivalent to protogen.Options.Run, except that files without a
+// go_package option are allowed.
+func run() error {
+	in, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(in, req); err != nil {
+		return err
+	}
+
+	defaultGoImportPaths(req)
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		return err
+	}
+	if err := New(gen).Generate(); err != nil {
+		gen.Error(err)
+	}
+
+	out, err := proto.Marshal(gen.Response())
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+// defaultGoImportPaths maps each file without a go_package option to the
+// relative Go import path of its directory, which protogen otherwise rejects.
+// The Go import paths only matter when generating Go code, and most files that
+// GraphQL schemas are generated for have no need for one.
+//
+// The mappings are prepended as "M" parameters so that any mappings passed
+// explicitly to the plugin take precedence.
+func defaultGoImportPaths(req *pluginpb.CodeGeneratorRequest) {
+	var params []string
+	for _, file := range req.GetProtoFile() {
+		if file.GetOptions().GetGoPackage() != "" {
+			continue
+		}
+		// Import paths must contain a dot or a slash.
+		importPath := path.Dir(file.GetName())
+		if importPath != "." {
+			importPath = "./" + importPath
+		}
+		params = append(params, fmt.Sprintf("M%s=%s", file.GetName(), importPath))
+	}
+	if req.GetParameter() != "" {
+		params = append(params, req.GetParameter())
+	}
+	req.Parameter = proto.String(strings.Join(params, ","))
 }
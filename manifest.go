@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+	"github.com/martinxsliu/protoc-gen-graphql/mapper"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+// manifest describes the protobuf descriptors that each generated GraphQL type
+// and field are mapped from, so that resolvers can be bound to them.
+type manifest struct {
+	Files []*manifestFile `json:"files"`
+}
+
+type manifestFile struct {
+	Name  string          `json:"name"`
+	Types []*manifestType `json:"types"`
+}
+
+type manifestType struct {
+	Name string `json:"name"`
+	// One of "OBJECT", "INPUT_OBJECT", "ENUM", "UNION", "INTERFACE", "SCALAR",
+	// "SCHEMA" or "DIRECTIVE".
+	Kind   string `json:"kind"`
+	Extend bool   `json:"extend,omitempty"`
+	// Full name of the protobuf message, enum, oneof or service that the type
+	// is mapped from.
+	Proto  string               `json:"proto,omitempty"`
+	Fields []*manifestField     `json:"fields,omitempty"`
+	Values []*manifestEnumValue `json:"values,omitempty"`
+}
+
+type manifestField struct {
+	Name        string `json:"name"`
+	ProtoField  string `json:"protoField,omitempty"`
+	ProtoNumber int32  `json:"protoNumber,omitempty"`
+	ProtoOneof  string `json:"protoOneof,omitempty"`
+	// Set for fields that resolve the message referenced by a foreign key.
+	ForeignKey *manifestForeignKey `json:"foreignKey,omitempty"`
+	// Set for root fields that call a gRPC method.
+	Method *manifestMethod `json:"method,omitempty"`
+	// Set for root fields that return the type of a gRPC service.
+	Service string `json:"service,omitempty"`
+}
+
+type manifestForeignKey struct {
+	ProtoField  string `json:"protoField"`
+	ProtoNumber int32  `json:"protoNumber"`
+	Proto       string `json:"proto"`
+	// nil if no gRPC method loads the referenced message.
+	Loader *manifestLoader `json:"loader,omitempty"`
+}
+
+type manifestMethod struct {
+	Service         string `json:"service"`
+	Method          string `json:"method"`
+	ClientStreaming bool   `json:"clientStreaming,omitempty"`
+	ServerStreaming bool   `json:"serverStreaming,omitempty"`
+	// Set for methods whose field returns a field of the response message.
+	ResponsePath []string `json:"responsePath,omitempty"`
+	// Set for methods that are mapped to Relay connections.
+	Connection *manifestConnection `json:"connection,omitempty"`
+}
+
+// manifestConnection describes how the arguments and fields of a connection
+// map to the AIP-158 pagination fields of its method.
+type manifestConnection struct {
+	// Request fields that the 'first' and 'after' arguments are mapped to.
+	PageSizeField  string `json:"pageSizeField"`
+	PageTokenField string `json:"pageTokenField"`
+	// Response fields that the edges and page info are mapped from.
+	NextPageTokenField string `json:"nextPageTokenField"`
+	ItemsField         string `json:"itemsField"`
+}
+
+type manifestLoader struct {
+	Service            string   `json:"service"`
+	Method             string   `json:"method"`
+	Many               bool     `json:"many"`
+	RequestFieldPath   []string `json:"requestFieldPath"`
+	ResponseFieldPath  []string `json:"responseFieldPath"`
+	ObjectKeyFieldPath []string `json:"objectKeyFieldPath,omitempty"`
+}
+
+type manifestEnumValue struct {
+	Name        string `json:"name"`
+	ProtoName   string `json:"protoName"`
+	ProtoNumber int32  `json:"protoNumber"`
+}
+
+// generateManifest generates a JSON manifest that maps each generated type and
+// field to the protobuf descriptors that they are mapped from.
+func (g *Generator) generateManifest(params *parameters.Parameters) {
+	b := &manifestBuilder{
+		mapper:       g.mapper,
+		typeSources:  make(map[graphql.Type]string),
+		rootMethods:  make(map[*graphql.Field]*descriptor.Method),
+		rootServices: make(map[*graphql.Field]*descriptor.Service),
+	}
+	b.buildSources()
+
+	m := &manifest{}
+	for _, group := range g.outputGroups(params) {
+		file := &manifestFile{Name: group.fileName}
+		for _, gqlType := range group.types {
+			file.Types = append(file.Types, b.manifestType(gqlType))
+		}
+		m.Files = append(m.Files, file)
+	}
+
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	genFile := g.gen.NewGeneratedFile(params.ManifestFileName, "github.com/not-a-real-import")
+	_, _ = genFile.Write(content)
+	_, _ = genFile.Write([]byte("\n"))
+}
+
+type manifestBuilder struct {
+	mapper *mapper.Mapper
+	// Maps graphql types to the full names of their protobuf descriptors.
+	typeSources  map[graphql.Type]string
+	rootMethods  map[*graphql.Field]*descriptor.Method
+	rootServices map[*graphql.Field]*descriptor.Service
+}
+
+func (b *manifestBuilder) buildSources() {
+	for _, m := range b.mapper.MessageMappers {
+		name := protoName(m.Descriptor.FullName)
+		if m.Object != nil {
+			b.typeSources[m.Object] = name
+		}
+		if m.Input != nil {
+			b.typeSources[m.Input] = name
+		}
+		for _, oneof := range m.Oneofs {
+			b.typeSources[oneof.Type()] = name + "." + oneof.Descriptor.Proto.GetName()
+			for _, object := range oneof.Objects {
+				b.typeSources[object] = name
+			}
+			if oneof.Input != nil {
+				b.typeSources[oneof.Input] = name + "." + oneof.Descriptor.Proto.GetName()
+			}
+		}
+	}
+
+	for _, m := range b.mapper.EnumMappers {
+		b.typeSources[m.Enum] = protoName(m.Descriptor.FullName)
+	}
+
+	for _, m := range b.mapper.ServiceMappers {
+		name := protoName(m.Descriptor.FullName)
+		for _, methods := range []*mapper.MethodsMapper{m.Methods, m.Queries, m.Mutations, m.Subscriptions} {
+			if methods == nil {
+				continue
+			}
+			if methods.ExtendRootObject != nil {
+				for _, field := range methods.ExtendRootObject.Fields {
+					b.rootServices[field] = m.Descriptor
+				}
+			}
+			if methods.Object != nil {
+				b.typeSources[methods.Object] = name
+				// The fields of a methods mapper's object correspond to its methods.
+				for i, field := range methods.Object.Fields {
+					b.rootMethods[field] = methods.Methods[i]
+				}
+			}
+		}
+	}
+}
+
+func (b *manifestBuilder) manifestType(gqlType graphql.Type) *manifestType {
+	t := &manifestType{
+		Name:  gqlType.TypeName(),
+		Proto: b.typeSources[gqlType],
+	}
+
+	switch gqlType := gqlType.(type) {
+	case *graphql.Object:
+		t.Kind = "OBJECT"
+		t.Fields = b.manifestFields(gqlType.Fields)
+	case *graphql.ExtendObject:
+		t.Kind = "OBJECT"
+		t.Extend = true
+		t.Fields = b.manifestFields(gqlType.Fields)
+	case *graphql.Input:
+		t.Kind = "INPUT_OBJECT"
+		t.Fields = b.manifestFields(gqlType.Fields)
+	case *graphql.Enum:
+		t.Kind = "ENUM"
+		for _, value := range gqlType.Values {
+			v := &manifestEnumValue{Name: value.Name}
+			if d, ok := b.mapper.EnumValueDescriptors[value]; ok {
+				v.ProtoName = d.Proto.GetName()
+				v.ProtoNumber = d.Proto.GetNumber()
+			}
+			t.Values = append(t.Values, v)
+		}
+	case *graphql.Union:
+		t.Kind = "UNION"
+	case *graphql.Interface:
+		t.Kind = "INTERFACE"
+		t.Fields = b.manifestFields(gqlType.Fields)
+	case *graphql.Scalar:
+		t.Kind = "SCALAR"
+	case *graphql.ExtendSchema:
+		t.Kind = "SCHEMA"
+		t.Extend = true
+	case *graphql.Directive:
+		t.Kind = "DIRECTIVE"
+	}
+	return t
+}
+
+func (b *manifestBuilder) manifestFields(fields []*graphql.Field) []*manifestField {
+	var manifestFields []*manifestField
+	for _, field := range fields {
+		f := &manifestField{Name: field.Name}
+
+		if d, ok := b.mapper.FieldDescriptors[field]; ok {
+			if d.IsOneof {
+				f.ProtoOneof = d.Name
+			} else {
+				f.ProtoField = d.Proto.GetName()
+				f.ProtoNumber = d.Proto.GetNumber()
+			}
+		}
+
+		if d, ok := b.mapper.ForeignKeyFields[field]; ok {
+			f.ForeignKey = &manifestForeignKey{
+				ProtoField:  d.Proto.GetName(),
+				ProtoNumber: d.Proto.GetNumber(),
+				Proto:       protoName(d.ForeignKey.FullName),
+			}
+			if loader, ok := b.mapper.Loaders[d.ForeignKey.FullName]; ok {
+				f.ForeignKey.Loader = &manifestLoader{
+					Service:            protoName(loader.Method.Service.FullName),
+					Method:             loader.Method.Proto.GetName(),
+					Many:               loader.Many,
+					RequestFieldPath:   loader.RequestFieldPath,
+					ResponseFieldPath:  loader.ResponseFieldPath,
+					ObjectKeyFieldPath: loader.ObjectKeyFieldPath,
+				}
+			}
+		}
+
+		if method, ok := b.rootMethods[field]; ok {
+			f.Method = &manifestMethod{
+				Service:         protoName(method.Service.FullName),
+				Method:          method.Proto.GetName(),
+				ClientStreaming: method.Proto.GetClientStreaming(),
+				ServerStreaming: method.Proto.GetServerStreaming(),
+			}
+			if path := method.Options.GetResponsePath(); path != "" {
+				f.Method.ResponsePath = strings.Split(path, ".")
+			}
+			if connection, ok := b.mapper.Connections[method]; ok {
+				f.Method.Connection = &manifestConnection{
+					PageSizeField:      connection.PageSizeField.Name,
+					PageTokenField:     connection.PageTokenField.Name,
+					NextPageTokenField: connection.NextPageTokenField.Name,
+					ItemsField:         connection.ItemsField.Name,
+				}
+			}
+		}
+		if service, ok := b.rootServices[field]; ok {
+			f.Service = protoName(service.FullName)
+		}
+
+		manifestFields = append(manifestFields, f)
+	}
+	return manifestFields
+}
+
+// protoName returns a fully qualified protobuf name without the leading '.'.
+func protoName(fullName string) string {
+	return strings.TrimPrefix(fullName, ".")
+}
@@ -0,0 +1,79 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+// flattenArguments returns true if the fields of a method's request message
+// are mapped to separate arguments. Request messages with oneofs are only
+// flattened if the oneofs themselves are flattened, otherwise the method
+// falls back to a single input argument.
+func (m *Mapper) flattenArguments(method *descriptor.Method) bool {
+	if !m.Params.FlattenArguments && !method.Options.GetFlattenArguments() {
+		return false
+	}
+	request := m.Messages[method.Proto.GetInputType()]
+	return len(request.Oneofs) == 0 || m.Params.OneofStyle == parameters.OneofStyleFlatten
+}
+
+// inputRequests returns the request messages of the methods that take an
+// input argument. Request messages that are only used by methods with
+// flattened arguments need no input type of their own.
+func (m *Mapper) inputRequests() map[string]bool {
+	requests := make(map[string]bool)
+	for _, filePb := range m.FilePbs {
+		for _, service := range m.Files[filePb.GetName()].Services {
+			for _, method := range service.Methods {
+				if !m.flattenArguments(method) {
+					requests[method.Proto.GetInputType()] = true
+				}
+			}
+		}
+	}
+	return requests
+}
+
+// buildFlattenedRequestMapper maps a request message whose fields are only
+// used as flattened arguments. Its object type is built as usual, but only the
+// messages of its fields get input types.
+func (m *Mapper) buildFlattenedRequestMapper(request *descriptor.Message) {
+	m.buildMessageMapper(request, false)
+	for _, field := range request.Proto.GetField() {
+		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			if fieldMessage := m.Messages[field.GetTypeName()]; !m.isJSONMapEntry(fieldMessage) {
+				m.buildMessageMapper(fieldMessage, true)
+			}
+		}
+	}
+}
+
+// flattenedArguments returns an argument for each field of the input type of
+// a request message, other than the pagination fields of a connection.
+func (m *Mapper) flattenedArguments(request *descriptor.Message, connection *ConnectionMapper) []*graphql.Argument {
+	var arguments []*graphql.Argument
+	for _, field := range m.graphqlFields(request, true) {
+		d := m.FieldDescriptors[field]
+		if connection != nil && (d == connection.PageSizeField || d == connection.PageTokenField) {
+			continue
+		}
+
+		argument := &graphql.Argument{
+			Name:        field.Name,
+			Description: field.Description,
+			TypeName:    field.TypeName,
+			Default:     field.Default,
+			Modifiers:   field.Modifiers,
+			Directives:  field.Directives,
+		}
+		// Unlike input fields, arguments can be deprecated.
+		if d != nil && d.Proto.GetOptions().GetDeprecated() {
+			argument.Directives = append(argument.Directives, "deprecated")
+		}
+		arguments = append(arguments, argument)
+	}
+	return arguments
+}
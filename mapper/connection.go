@@ -0,0 +1,175 @@
+package mapper
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+)
+
+// ConnectionMapper maps a gRPC method that follows the AIP-158 pagination
+// conventions to a Relay connection.
+type ConnectionMapper struct {
+	Method *descriptor.Method
+	// Fields of the request message that the 'first' and 'after' arguments
+	// are mapped to.
+	PageSizeField  *descriptor.Field
+	PageTokenField *descriptor.Field
+	// Fields of the response message that the connection is mapped from.
+	NextPageTokenField *descriptor.Field
+	ItemsField         *descriptor.Field
+	// Connection and edge types are shared by all methods with the same
+	// item type.
+	Connection *graphql.Object
+	Edge       *graphql.Object
+}
+
+func (m *Mapper) buildConnectionRequests() {
+	for _, filePb := range m.FilePbs {
+		for _, service := range m.Files[filePb.GetName()].Services {
+			for _, method := range service.Methods {
+				if method.Options.GetConnection() {
+					m.ConnectionRequests[method.Proto.GetInputType()] = true
+				}
+			}
+		}
+	}
+}
+
+// isPaginationField returns whether a field is the page_size or page_token
+// field of the request message of a connection method. They are mapped to the
+// 'first' and 'after' arguments, so they are left out of the request's input.
+func (m *Mapper) isPaginationField(message *descriptor.Message, field *descriptor.Field) bool {
+	if !m.ConnectionRequests[message.FullName] || field.IsOneof ||
+		field.Proto.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return false
+	}
+	switch field.Name {
+	case "page_size":
+		return field.Proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_INT32
+	case "page_token":
+		return field.Proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING
+	}
+	return false
+}
+
+// buildConnectionMapper returns the connection of a method, or nil if the
+// method does not follow the pagination conventions.
+func (m *Mapper) buildConnectionMapper(method *descriptor.Method) *ConnectionMapper {
+	request := m.Messages[method.Proto.GetInputType()]
+	response := m.Messages[method.Proto.GetOutputType()]
+
+	mapper := &ConnectionMapper{
+		Method:             method,
+		PageSizeField:      m.paginationField(method, request, "page_size", descriptorpb.FieldDescriptorProto_TYPE_INT32),
+		PageTokenField:     m.paginationField(method, request, "page_token", descriptorpb.FieldDescriptorProto_TYPE_STRING),
+		NextPageTokenField: m.paginationField(method, response, "next_page_token", descriptorpb.FieldDescriptorProto_TYPE_STRING),
+	}
+	valid := mapper.PageSizeField != nil && mapper.PageTokenField != nil && mapper.NextPageTokenField != nil
+
+	for _, field := range response.Fields {
+		if field.IsOneof ||
+			field.Proto.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED ||
+			field.Proto.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+			m.Messages[field.Proto.GetTypeName()].IsMap {
+			continue
+		}
+		if mapper.ItemsField != nil {
+			m.Diagnostics.Errorf(method.Location, "connection method %s must have a single repeated message field in its response, got %s and %s",
+				fullMethodName(method), mapper.ItemsField.Name, field.Name)
+			valid = false
+			break
+		}
+		mapper.ItemsField = field
+	}
+	if mapper.ItemsField == nil {
+		m.Diagnostics.Errorf(method.Location, "connection method %s must have a repeated message field in its response", fullMethodName(method))
+		valid = false
+	}
+	if !valid {
+		return nil
+	}
+
+	itemType := mapper.ItemsField.Proto.GetTypeName()
+	for _, connection := range m.Connections {
+		if connection.ItemsField.Proto.GetTypeName() == itemType {
+			mapper.Connection = connection.Connection
+			mapper.Edge = connection.Edge
+		}
+	}
+	if mapper.Connection == nil {
+		// The item's name is already rewritten, so the connection and edge
+		// names are not passed through the rewrites again.
+		itemName := m.ObjectNames[itemType]
+		element := " of method " + fullMethodName(method)
+		mapper.Edge = &graphql.Object{
+			Name: m.claimTypeName("edge"+element, method.Location, itemName+"Edge"),
+			Fields: []*graphql.Field{
+				{Name: "node", TypeName: itemName, Modifiers: graphql.TypeModifierNonNull},
+				{Name: "cursor", TypeName: graphql.ScalarString.TypeName()},
+			},
+		}
+		mapper.Connection = &graphql.Object{
+			Name: m.claimTypeName("connection"+element, method.Location, itemName+"Connection"),
+			Fields: []*graphql.Field{
+				{
+					Name:      "edges",
+					TypeName:  mapper.Edge.Name,
+					Modifiers: graphql.TypeModifierNonNull | graphql.TypeModifierList | graphql.TypeModifierNonNullList,
+				},
+				{Name: "pageInfo", TypeName: m.pageInfo(method).Name, Modifiers: graphql.TypeModifierNonNull},
+			},
+		}
+	}
+
+	m.Connections[method] = mapper
+	return mapper
+}
+
+// pageInfo returns the Relay PageInfo type that is shared by all connections.
+// Its name is claimed by the first connection method.
+func (m *Mapper) pageInfo(method *descriptor.Method) *graphql.Object {
+	if m.PageInfo == nil {
+		m.PageInfo = &graphql.Object{
+			Name: m.claimTypeName("page info of connections", method.Location, "PageInfo"),
+			Fields: []*graphql.Field{
+				{Name: "hasNextPage", TypeName: graphql.ScalarBoolean.TypeName(), Modifiers: graphql.TypeModifierNonNull},
+				{Name: "hasPreviousPage", TypeName: graphql.ScalarBoolean.TypeName(), Modifiers: graphql.TypeModifierNonNull},
+				{Name: "startCursor", TypeName: graphql.ScalarString.TypeName()},
+				{Name: "endCursor", TypeName: graphql.ScalarString.TypeName()},
+			},
+		}
+	}
+	return m.PageInfo
+}
+
+// paginationField returns the pagination field of a connection method's
+// request or response message, or nil if it has none.
+func (m *Mapper) paginationField(method *descriptor.Method, message *descriptor.Message, name string, fieldType descriptorpb.FieldDescriptorProto_Type) *descriptor.Field {
+	for _, field := range message.Fields {
+		if !field.IsOneof && field.Name == name &&
+			field.Proto.GetType() == fieldType &&
+			field.Proto.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			return field
+		}
+	}
+	m.Diagnostics.Errorf(method.Location, "connection method %s must have a %s field %s in %s",
+		fullMethodName(method), strings.ToLower(strings.TrimPrefix(fieldType.String(), "TYPE_")), name, strings.TrimPrefix(message.FullName, "."))
+	return nil
+}
+
+// fullMethodName returns the fully qualified name of a gRPC method.
+func fullMethodName(method *descriptor.Method) string {
+	return strings.TrimPrefix(method.Service.FullName, ".") + "." + method.Proto.GetName()
+}
+
+// connectionArguments returns the Relay pagination arguments of a connection
+// field.
+func connectionArguments() []*graphql.Argument {
+	return []*graphql.Argument{
+		{Name: "first", TypeName: graphql.ScalarInt.TypeName()},
+		{Name: "after", TypeName: graphql.ScalarString.TypeName()},
+	}
+}
@@ -0,0 +1,95 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+)
+
+// defaultValue returns the default value of an input field in GraphQL syntax,
+// or an empty string if it has none. Defaults are taken from the field's
+// explicit default, or with the 'zero_defaults' parameter, the zero value of
+// fields with implicit presence.
+func (m *Mapper) defaultValue(f *descriptor.Field, field *graphql.Field) string {
+	proto := f.Proto
+	if proto.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return ""
+	}
+	// At most one member of a oneof can be set, so none of them have defaults.
+	if proto.OneofIndex != nil && !proto.GetProto3Optional() {
+		return ""
+	}
+
+	value := proto.GetDefaultValue()
+	if proto.DefaultValue == nil {
+		if !m.Params.ZeroDefaults || f.Presence != descriptorpb.FeatureSet_IMPLICIT {
+			return ""
+		}
+		value = zeroValue(proto.GetType())
+	}
+
+	switch proto.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return ""
+
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		// Explicit defaults of bytes fields are C escaped, which can't be
+		// represented as the base64 strings that bytes are serialized as.
+		if proto.DefaultValue != nil {
+			return ""
+		}
+		return graphql.QuoteString(value)
+
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		return m.enumDefaultValue(proto.GetTypeName(), proto.DefaultValue)
+
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		// Infinity and NaN have no GraphQL literal.
+		switch value {
+		case "inf", "-inf", "nan":
+			return ""
+		}
+	}
+
+	// Scalars such as 64 bit integers may be mapped to strings.
+	if field.TypeName == graphql.ScalarString.TypeName() {
+		return graphql.QuoteString(value)
+	}
+	return value
+}
+
+// enumDefaultValue returns the name of the graphql enum value that is the
+// default of an enum field, given the name of the protobuf enum value or nil
+// for the zero value. Aliases are resolved to the value they are collapsed
+// into, and there is no default if the value is dropped or skipped.
+func (m *Mapper) enumDefaultValue(enumTypeName string, protoValueName *string) string {
+	enumMapper := m.EnumMappers[enumTypeName]
+
+	var number int32
+	if protoValueName != nil {
+		for _, value := range enumMapper.Descriptor.Values {
+			if value.Proto.GetName() == *protoValueName {
+				number = value.Proto.GetNumber()
+			}
+		}
+	}
+
+	for _, enumValue := range enumMapper.Enum.Values {
+		if m.EnumValueDescriptors[enumValue].Proto.GetNumber() == number {
+			return enumValue.Name
+		}
+	}
+	return ""
+}
+
+func zeroValue(protoType descriptorpb.FieldDescriptorProto_Type) string {
+	switch protoType {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return ""
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return "false"
+	default:
+		return "0"
+	}
+}
@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/diagnostics"
+)
+
+// federationKeys returns the Apollo Federation keys of a message's object.
+// Keys are taken from the message's 'key' options, or otherwise derived from
+// the object key field path of the message's 'load_many' loader.
+func (m *Mapper) federationKeys(message *descriptor.Message) []string {
+	if keys := message.Options.GetKey(); len(keys) > 0 {
+		return keys
+	}
+
+	loader, ok := m.Loaders[message.FullName]
+	if !ok || !loader.Many {
+		return nil
+	}
+	key, ok := m.selectionSet(message, loader.ObjectKeyFieldPath, loader.Method.Location)
+	if !ok {
+		return nil
+	}
+	return []string{key}
+}
+
+// selectionSet returns the GraphQL selection set that selects the field at
+// the protobuf field path, e.g. "key { id }" for the path "key.id". Returns
+// false if the path is invalid, in which case an error is recorded at the
+// location.
+func (m *Mapper) selectionSet(message *descriptor.Message, path []string, location diagnostics.Location) (string, bool) {
+	var b strings.Builder
+	for i, name := range path {
+		if message == nil {
+			m.Diagnostics.Errorf(location, "invalid field path for key %s: %s is not a message field", strings.Join(path, "."), path[i-1])
+			return "", false
+		}
+
+		var field *descriptor.Field
+		for _, f := range message.Fields {
+			if f.Name == name && !f.IsOneof {
+				field = f
+			}
+		}
+		if field == nil {
+			m.Diagnostics.Errorf(location, "unknown field %s in %s for key %s", name, strings.TrimPrefix(message.FullName, "."), strings.Join(path, "."))
+			return "", false
+		}
+
+		if i > 0 {
+			b.WriteString(" { ")
+		}
+		b.WriteString(m.fieldName(field))
+		message = m.Messages[field.Proto.GetTypeName()]
+	}
+	for i := 1; i < len(path); i++ {
+		b.WriteString(" }")
+	}
+	return b.String(), true
+}
+
+func federationDirectives(message *descriptor.Message, keys []string) []string {
+	var directives []string
+	for _, key := range keys {
+		directives = append(directives, fmt.Sprintf("key(fields: %s)", strconv.Quote(key)))
+	}
+	if message.Options.GetShareable() {
+		directives = append(directives, "shareable")
+	}
+	return directives
+}
@@ -3,21 +3,26 @@ package mapper
 import (
 	"fmt"
 	"github.com/martinxsliu/protoc-gen-graphql/parameters"
-	"os"
 	"strings"
 
 	"google.golang.org/protobuf/types/descriptorpb"
 
 	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/diagnostics"
 	"github.com/martinxsliu/protoc-gen-graphql/graphql"
 )
 
 type Mapper struct {
 	FilePbs []*descriptorpb.FileDescriptorProto
 
-	Params                *parameters.Parameters
-	FieldNameTransformer  func(string) string
-	MethodNameTransformer func(string) string
+	Params                   *parameters.Parameters
+	FieldNameTransformer     func(string) string
+	MethodNameTransformer    func(string) string
+	EnumValueNameTransformer func(string) string
+
+	// Collects the errors and warnings found while mapping. Definitions with
+	// errors are skipped where possible, so that all errors are reported.
+	Diagnostics *diagnostics.Collector
 
 	// Maps file names to descriptors.
 	Files map[string]*descriptor.File
@@ -30,31 +35,65 @@ type Mapper struct {
 	// Maps protobuf messages and enums to graphql type names.
 	ObjectNames map[string]string
 	InputNames  map[string]string
+	// Maps graphql type names to the protobuf elements that claimed them, and
+	// descriptions of protobuf elements to the type names they claimed.
+	TypeNameOwners   map[string]*TypeNameOwner
+	ClaimedTypeNames map[string]string
 
 	// Maps protobuf types to graphql types.
 	MessageMappers map[string]*MessageMapper
 	EnumMappers    map[string]*EnumMapper
 	ServiceMappers map[string]*ServiceMapper
+
+	// Maps graphql fields and enum values to the protobuf descriptors that
+	// they are mapped from.
+	FieldDescriptors     map[*graphql.Field]*descriptor.Field
+	ForeignKeyFields     map[*graphql.Field]*descriptor.Field
+	EnumValueDescriptors map[*graphql.EnumValue]*descriptor.EnumValue
+
+	// Maps gRPC methods with the 'connection' option to their connections.
+	Connections map[*descriptor.Method]*ConnectionMapper
+	// Names of the request messages of methods with the 'connection' option.
+	ConnectionRequests map[string]bool
+	// The Relay PageInfo type, only set if there are any connections.
+	PageInfo *graphql.Object
 }
 
 type MessageMapper struct {
 	Descriptor *descriptor.Message
 	Empty      bool
-	Object     *graphql.Object
-	Input      *graphql.Input
-	Oneofs     []*OneofMapper
+	// Apollo Federation keys of the object, only set with the 'federation'
+	// parameter.
+	Keys   []string
+	Object *graphql.Object
+	Input  *graphql.Input
+	Oneofs []*OneofMapper
 }
 
 type OneofMapper struct {
 	Descriptor *descriptor.Oneof
-	Union      *graphql.Union
-	Objects    []*graphql.Object
-	Input      *graphql.Input
+	// Either Union or Interface is set, depending on the 'oneof_style'
+	// parameter.
+	Union     *graphql.Union
+	Interface *graphql.Interface
+	Objects   []*graphql.Object
+	Input     *graphql.Input
+}
+
+// Type returns the union or interface type of the oneof.
+func (m *OneofMapper) Type() graphql.Type {
+	if m.Interface != nil {
+		return m.Interface
+	}
+	return m.Union
 }
 
 type EnumMapper struct {
 	Descriptor *descriptor.Enum
 	Enum       *graphql.Enum
+	// True if the zero value is dropped by the 'enum_unspecified' parameter,
+	// in which case fields of the enum are nullable.
+	ZeroDropped bool
 }
 
 type ServiceMapper struct {
@@ -76,43 +115,89 @@ type MethodsMapper struct {
 
 // New creates a new Mapper with all mappings populated from the provided file
 // descriptors. The provided file descriptors must be in topological order.
-func New(filePbs []*descriptorpb.FileDescriptorProto, params *parameters.Parameters) *Mapper {
+// Errors and warnings are recorded in diags, and the mappings are only
+// complete if no errors were recorded.
+func New(filePbs []*descriptorpb.FileDescriptorProto, params *parameters.Parameters, diags *diagnostics.Collector) *Mapper {
 	m := &Mapper{
-		FilePbs: filePbs,
-		Params:  params,
+		FilePbs:     filePbs,
+		Params:      params,
+		Diagnostics: diags,
 
 		Files:    make(map[string]*descriptor.File),
 		Messages: make(map[string]*descriptor.Message),
 		Enums:    make(map[string]*descriptor.Enum),
 		Loaders:  make(map[string]*descriptor.Loader),
 
-		ObjectNames: make(map[string]string),
-		InputNames:  make(map[string]string),
+		ObjectNames:      make(map[string]string),
+		InputNames:       make(map[string]string),
+		TypeNameOwners:   make(map[string]*TypeNameOwner),
+		ClaimedTypeNames: make(map[string]string),
 
 		MessageMappers: make(map[string]*MessageMapper),
 		EnumMappers:    make(map[string]*EnumMapper),
 		ServiceMappers: make(map[string]*ServiceMapper),
+
+		FieldDescriptors:     make(map[*graphql.Field]*descriptor.Field),
+		ForeignKeyFields:     make(map[*graphql.Field]*descriptor.Field),
+		EnumValueDescriptors: make(map[*graphql.EnumValue]*descriptor.EnumValue),
+
+		Connections:        make(map[*descriptor.Method]*ConnectionMapper),
+		ConnectionRequests: make(map[string]bool),
 	}
 
 	switch params.FieldName {
-	case parameters.FieldNameDefault, "":
+	case parameters.FieldNameDefault:
 		m.FieldNameTransformer = LowerUnderscoreToLowerCamelTransformer
-		m.MethodNameTransformer = UpperCamelToLowerCamelTransformer
 	case parameters.FieldNamePreserve:
 		m.FieldNameTransformer = PreserveTransformer
+	case parameters.FieldNameJSON:
+		// Fields are mapped to their json_name, this transforms names that
+		// are not field names, e.g. of map entry fields, the same way.
+		m.FieldNameTransformer = JSONNameTransformer
+	case parameters.FieldNameSnakeCase:
+		m.FieldNameTransformer = SnakeCaseTransformer
+	case parameters.FieldNameUpperCamelCase:
+		m.FieldNameTransformer = UpperCamelTransformer
+	}
+
+	switch params.MethodName {
+	case parameters.FieldNameDefault:
+		m.MethodNameTransformer = UpperCamelToLowerCamelTransformer
+	case parameters.FieldNamePreserve:
 		m.MethodNameTransformer = PreserveTransformer
+	case parameters.FieldNameSnakeCase:
+		m.MethodNameTransformer = SnakeCaseTransformer
+	case parameters.FieldNameUpperCamelCase:
+		m.MethodNameTransformer = UpperCamelTransformer
+	}
+
+	switch params.EnumValueName {
+	case parameters.EnumValueNamePreserve:
+		m.EnumValueNameTransformer = PreserveTransformer
+	case parameters.EnumValueNameUpperSnakeCase:
+		m.EnumValueNameTransformer = UpperSnakeCaseTransformer
+	case parameters.EnumValueNameLowerCamelCase:
+		m.EnumValueNameTransformer = LowerCamelTransformer
+	case parameters.EnumValueNameUpperCamelCase:
+		m.EnumValueNameTransformer = UpperCamelTransformer
+	case parameters.EnumValueNameSnakeCase:
+		m.EnumValueNameTransformer = SnakeCaseTransformer
 	}
 
 	m.buildDescriptorMaps()
+	if params.Federation != parameters.FederationNone {
+		m.claimFederationTypeNames()
+	}
 	m.buildTypeMaps()
 	m.buildTypeLoader()
+	m.buildConnectionRequests()
 	m.buildMappers()
 	return m
 }
 
 func (m *Mapper) buildDescriptorMaps() {
 	for _, filePb := range m.FilePbs {
-		file := descriptor.WrapFile(filePb)
+		file := descriptor.WrapFile(filePb, m.Diagnostics)
 		m.Files[filePb.GetName()] = file
 		for _, enum := range file.Enums {
 			m.Enums[enum.FullName] = enum
@@ -127,7 +212,8 @@ func (m *Mapper) buildTypeMaps() {
 	for _, filePb := range m.FilePbs {
 		file := m.Files[filePb.GetName()]
 		for _, enum := range file.Enums {
-			m.ObjectNames[enum.FullName] = m.enumName(enum)
+			element := "enum " + strings.TrimPrefix(enum.FullName, ".")
+			m.ObjectNames[enum.FullName] = m.claimTypeName(element, enum.Location, m.enumName(enum))
 		}
 
 		for _, message := range file.Messages {
@@ -138,21 +224,27 @@ func (m *Mapper) buildTypeMaps() {
 }
 
 func (m *Mapper) buildTypeLoader() {
-	for _, file := range m.Files {
-		for _, service := range file.Services {
+	for _, filePb := range m.FilePbs {
+		for _, service := range m.Files[filePb.GetName()].Services {
 			for _, method := range service.Methods {
 				for _, loader := range method.Loaders {
 					if m.Messages[loader.FullName] == nil {
-						panic(fmt.Sprintf("unknown type for loader: %s", loader.FullName))
+						m.Diagnostics.Errorf(method.Location, "unknown type for loader: %s", strings.TrimPrefix(loader.FullName, "."))
+						continue
 					}
 					if _, ok := m.Loaders[loader.FullName]; ok {
-						panic(fmt.Sprintf("multiple loaders specified for Protobuf type: %s", loader.FullName))
+						m.Diagnostics.Errorf(method.Location, "multiple loaders specified for Protobuf type: %s", strings.TrimPrefix(loader.FullName, "."))
+						continue
 					}
 					m.Loaders[loader.FullName] = loader
 				}
 			}
 		}
 	}
+
+	if m.Params.InferOperations == parameters.InferOperationsAIP {
+		m.buildAIPLoaders()
+	}
 }
 
 func (m *Mapper) buildMessageTypeMaps(message *descriptor.Message, input bool) {
@@ -165,7 +257,11 @@ func (m *Mapper) buildMessageTypeMaps(message *descriptor.Message, input bool) {
 		return
 	}
 
-	nameMap[message.FullName] = m.messageName(message, input)
+	element := "message " + strings.TrimPrefix(message.FullName, ".")
+	if input {
+		element = "input of " + element
+	}
+	nameMap[message.FullName] = m.claimTypeName(element, message.Location, m.messageName(message, input))
 
 	for _, field := range message.Proto.GetField() {
 		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
@@ -175,6 +271,7 @@ func (m *Mapper) buildMessageTypeMaps(message *descriptor.Message, input bool) {
 }
 
 func (m *Mapper) buildMappers() {
+	inputRequests := m.inputRequests()
 	for _, filePb := range m.FilePbs {
 		file := m.Files[filePb.GetName()]
 
@@ -183,19 +280,28 @@ func (m *Mapper) buildMappers() {
 			m.buildEnumMapper(enum)
 		}
 		for _, message := range file.Messages {
-			m.buildMessageMapper(message, false)
+			if !m.isJSONMapEntry(message) {
+				m.buildMessageMapper(message, false)
+			}
 		}
 
 		if m.Params.InputMode == parameters.InputModeAll {
 			for _, message := range file.Messages {
-				m.buildMessageMapper(message, true)
+				if !m.isJSONMapEntry(message) {
+					m.buildMessageMapper(message, true)
+				}
 			}
 		}
 
 		for _, service := range file.Services {
 			if m.Params.InputMode == parameters.InputModeService {
 				for _, method := range service.Proto.GetMethod() {
-					m.buildMessageMapper(m.Messages[method.GetInputType()], true)
+					request := m.Messages[method.GetInputType()]
+					if inputRequests[request.FullName] {
+						m.buildMessageMapper(request, true)
+					} else {
+						m.buildFlattenedRequestMapper(request)
+					}
 				}
 			}
 
@@ -248,6 +354,10 @@ func (m *Mapper) buildMessageMapper(message *descriptor.Message, input bool) {
 		Description: getComments(typeName),
 		Fields:      m.graphqlFields(message, false),
 	}
+	if m.Params.Federation != parameters.FederationNone {
+		mapper.Keys = m.federationKeys(message)
+		mapper.Object.Directives = federationDirectives(message, mapper.Keys)
+	}
 	if input {
 		typeName = m.InputNames[message.FullName]
 		mapper.Input = &graphql.Input{
@@ -257,56 +367,77 @@ func (m *Mapper) buildMessageMapper(message *descriptor.Message, input bool) {
 		}
 	}
 
+	// Flattened oneofs are inlined into their parent types, so they have no
+	// types of their own.
 	var oneofMappers []*OneofMapper
-	for _, oneof := range message.Oneofs {
-		oneofMappers = append(oneofMappers, m.buildOneofMapper(oneof, input))
+	if m.Params.OneofStyle != parameters.OneofStyleFlatten {
+		for _, oneof := range message.Oneofs {
+			oneofMappers = append(oneofMappers, m.buildOneofMapper(oneof, input))
+		}
 	}
 	mapper.Oneofs = oneofMappers
 
+	// The input of a message that only has a single oneof is itself a oneof
+	// input, so the members of the oneof are inlined into it.
+	if input && m.Params.OneofDirective && len(message.Fields) == 1 && message.Fields[0].IsOneof {
+		if len(oneofMappers) == 0 {
+			mapper.Input.Directives = append(mapper.Input.Directives, graphql.DirectiveOneOf)
+			m.validateOneofInput(mapper.Input, message.Location)
+		} else {
+			oneof := oneofMappers[0]
+			mapper.Input.Fields = oneof.Input.Fields
+			mapper.Input.Directives = append(mapper.Input.Directives, oneof.Input.Directives...)
+			oneof.Input = nil
+		}
+	}
+
 	for _, field := range message.Proto.GetField() {
 		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
-			m.buildMessageMapper(m.Messages[field.GetTypeName()], input)
+			if fieldMessage := m.Messages[field.GetTypeName()]; !m.isJSONMapEntry(fieldMessage) {
+				m.buildMessageMapper(fieldMessage, input)
+			}
 		}
 	}
 }
 
 func (m *Mapper) graphqlFields(message *descriptor.Message, input bool) []*graphql.Field {
 	var fields []*graphql.Field
-
-	if len(message.Fields) == 0 {
-		fields = append(fields, &graphql.Field{
-			Name:     "_empty",
-			TypeName: graphql.ScalarBoolean.TypeName(),
-		})
-		return fields
-	}
-
 	for _, field := range message.Fields {
-		if field.Options.GetSkip() {
+		if skipField(field, input) || (input && m.isPaginationField(message, field)) {
+			continue
+		}
+
+		if field.IsOneof && m.Params.OneofStyle == parameters.OneofStyleFlatten {
+			fields = append(fields, m.flattenedOneofFields(message, field, input)...)
 			continue
 		}
 
 		if field.IsOneof {
-			oneofObjectName := field.Name + "Oneof"
-			fields = append(fields, &graphql.Field{
+			oneofField := &graphql.Field{
 				Name:        m.fieldName(field),
 				Description: field.Comments,
-				TypeName: m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-					Namespace: message.File.Options.GetNamespace(),
-					Package:   message.Package,
-					TypeName:  append(message.TypeName, oneofObjectName),
-					Input:     input,
-				}),
-			})
+				TypeName:    m.oneofTypeName(message.Oneofs[field.OneofIndex], input),
+			}
+			m.FieldDescriptors[oneofField] = field
+			fields = append(fields, oneofField)
 			continue
 		}
 
-		fields = append(fields, m.graphqlField(field, input))
+		gqlField := m.graphqlField(field, input)
+		if input && field.Options.GetRequired() {
+			requireField(gqlField)
+		}
+		fields = append(fields, gqlField)
+
+		if !input && m.Params.MapStyle == parameters.MapStyleList && field.Options.GetType() == "" && m.mapEntry(field) != nil {
+			fields = append(fields, m.mapLookupField(field, gqlField))
+		}
 
 		if field.ForeignKey != nil && !input {
 			referencedObjectName, ok := m.ObjectNames[field.ForeignKey.FullName]
 			if !ok {
-				panic(fmt.Sprintf("unknown type for foreign key: %s", field.Options.GetForeignKey()))
+				m.Diagnostics.Errorf(field.Location, "unknown type for foreign key: %s", field.Options.GetForeignKey())
+				continue
 			}
 
 			var modifiers graphql.TypeModifier
@@ -314,11 +445,66 @@ func (m *Mapper) graphqlFields(message *descriptor.Message, input bool) []*graph
 				modifiers = graphql.TypeModifierList | graphql.TypeModifierNonNull
 			}
 
-			fields = append(fields, &graphql.Field{
+			foreignKeyField := &graphql.Field{
 				Name:      field.ForeignKey.FieldName,
 				TypeName:  referencedObjectName,
 				Modifiers: modifiers,
-			})
+			}
+			m.ForeignKeyFields[foreignKeyField] = field
+			fields = append(fields, foreignKeyField)
+		}
+	}
+
+	// GraphQL types must have fields, so messages without fields, or whose
+	// fields are all skipped, have a placeholder field.
+	if len(fields) == 0 {
+		fields = append(fields, &graphql.Field{
+			Name:     "_empty",
+			TypeName: graphql.ScalarBoolean.TypeName(),
+		})
+	}
+	return fields
+}
+
+// skipField returns true if a field is not generated for object or input
+// types.
+func skipField(field *descriptor.Field, input bool) bool {
+	if field.Options.GetSkip() {
+		return true
+	}
+	if input {
+		return field.Options.GetSkipInput()
+	}
+	return field.Options.GetSkipOutput()
+}
+
+// requireField makes a field non-null. Repeated fields are non-null lists.
+func requireField(field *graphql.Field) {
+	if strings.HasSuffix(field.TypeName, "!") {
+		return
+	}
+	if field.Modifiers&graphql.TypeModifierList > 0 {
+		field.Modifiers |= graphql.TypeModifierNonNullList
+	} else {
+		field.Modifiers |= graphql.TypeModifierNonNull
+	}
+}
+
+// flattenedOneofFields returns the fields of the members of a oneof, which
+// are all nullable as at most one of them is set.
+func (m *Mapper) flattenedOneofFields(message *descriptor.Message, field *descriptor.Field, input bool) []*graphql.Field {
+	var fields []*graphql.Field
+	for _, oneof := range message.Oneofs {
+		if oneof.Proto.GetName() != field.Name {
+			continue
+		}
+		for _, member := range oneof.Fields {
+			if skipField(member, input) {
+				continue
+			}
+			memberField := m.graphqlField(member, input)
+			memberField.Modifiers &^= graphql.TypeModifierNonNull
+			fields = append(fields, memberField)
 		}
 	}
 	return fields
@@ -329,6 +515,7 @@ func (m *Mapper) graphqlField(f *descriptor.Field, input bool) *graphql.Field {
 		Name:        m.fieldName(f),
 		Description: f.Comments,
 	}
+	m.FieldDescriptors[field] = f
 	if input {
 		field.Directives = f.Options.GetInputDirective()
 	} else {
@@ -345,46 +532,36 @@ func (m *Mapper) graphqlField(f *descriptor.Field, input bool) *graphql.Field {
 		return field
 	}
 
+	// Maps are always set, although they may be empty.
+	if entry := m.mapEntry(f); entry != nil && m.isJSONMapEntry(entry) {
+		field.TypeName = m.Params.MapScalarName
+		if !input {
+			field.Modifiers = graphql.TypeModifierNonNull
+		}
+		return field
+	}
+
 	proto := f.Proto
 	nullableScalars := m.nullableScalars(f, input)
 
 	switch proto.GetType() {
-	case descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES:
-		field.TypeName = graphql.ScalarString.TypeName()
-		if !nullableScalars {
-			field.Modifiers = graphql.TypeModifierNonNull
-		}
-
-	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+		descriptorpb.FieldDescriptorProto_TYPE_FLOAT, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
 		descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_TYPE_UINT32, descriptorpb.FieldDescriptorProto_TYPE_SINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_FIXED32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
-
-		field.TypeName = graphql.ScalarFloat.TypeName()
-		if !nullableScalars {
-			field.Modifiers = graphql.TypeModifierNonNull
-		}
-
-	case descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_SINT64,
-		descriptorpb.FieldDescriptorProto_TYPE_FIXED64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32, descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+		descriptorpb.FieldDescriptorProto_TYPE_BOOL:
 
-		if m.Params.JS64BitType == parameters.JS64BitTypeString {
-			field.TypeName = graphql.ScalarString.TypeName()
-		} else {
-			field.TypeName = graphql.ScalarFloat.TypeName()
-		}
-		if !nullableScalars {
-			field.Modifiers = graphql.TypeModifierNonNull
-		}
-
-	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
-		field.TypeName = graphql.ScalarBoolean.TypeName()
+		field.TypeName = m.scalarTypeName(proto.GetType())
 		if !nullableScalars {
 			field.Modifiers = graphql.TypeModifierNonNull
 		}
 
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
-		field.TypeName = m.EnumMappers[proto.GetTypeName()].Enum.Name
-		if !nullableScalars {
+		enumMapper := m.EnumMappers[proto.GetTypeName()]
+		field.TypeName = enumMapper.Enum.Name
+		if !nullableScalars && !enumMapper.ZeroDropped {
 			field.Modifiers = graphql.TypeModifierNonNull
 		}
 
@@ -401,7 +578,8 @@ func (m *Mapper) graphqlField(f *descriptor.Field, input bool) *graphql.Field {
 		}
 
 	default:
-		panic(fmt.Sprintf("unexpected protobuf descriptor type: %s", proto.GetType().String()))
+		m.Diagnostics.Errorf(f.Location, "unexpected protobuf descriptor type: %s", proto.GetType().String())
+		return field
 	}
 
 	field = m.graphqlSpecialTypes(field, proto.GetTypeName())
@@ -413,6 +591,10 @@ func (m *Mapper) graphqlField(f *descriptor.Field, input bool) *graphql.Field {
 		}
 	}
 
+	if input {
+		field.Default = m.defaultValue(f, field)
+	}
+
 	return field
 }
 
@@ -428,52 +610,110 @@ func (m *Mapper) graphqlSpecialTypes(field *graphql.Field, protoTypeName string)
 	}
 
 	if m.Params.WrappersAsNull {
-		switch protoTypeName {
-		case ".google.protobuf.FloatValue", ".google.protobuf.DoubleValue", ".google.protobuf.UInt32Value":
-			field.TypeName = graphql.ScalarFloat.TypeName()
-		case ".google.protobuf.StringValue", ".google.protobuf.BytesValue":
-			field.TypeName = graphql.ScalarString.TypeName()
-		case ".google.protobuf.Int64Value", ".google.protobuf.UInt64Value":
-			if m.Params.JS64BitType == parameters.JS64BitTypeString {
-				field.TypeName = graphql.ScalarString.TypeName()
-			} else {
-				field.TypeName = graphql.ScalarFloat.TypeName()
-			}
-		case ".google.protobuf.Int32Value":
+		if protoType, ok := wrapperTypes[protoTypeName]; ok {
+			field.TypeName = m.scalarTypeName(protoType)
+		}
+		// Int32Value is mapped to Int by default, unlike int32 fields.
+		if _, ok := m.Params.ScalarTypeNames[descriptorpb.FieldDescriptorProto_TYPE_INT32]; !ok && protoTypeName == ".google.protobuf.Int32Value" {
 			field.TypeName = graphql.ScalarInt.TypeName()
-		case ".google.protobuf.BoolValue":
-			field.TypeName = graphql.ScalarBoolean.TypeName()
 		}
 	}
 
 	return field
 }
 
+// Maps well known wrapper types to the protobuf scalar types that they wrap.
+var wrapperTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	".google.protobuf.FloatValue":  descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	".google.protobuf.DoubleValue": descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	".google.protobuf.Int32Value":  descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	".google.protobuf.UInt32Value": descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	".google.protobuf.Int64Value":  descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	".google.protobuf.UInt64Value": descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	".google.protobuf.StringValue": descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	".google.protobuf.BytesValue":  descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	".google.protobuf.BoolValue":   descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+}
+
+// scalarTypeName returns the GraphQL scalar type name for a protobuf scalar
+// type, using the mapping from the 'scalar' parameters if there is one.
+func (m *Mapper) scalarTypeName(protoType descriptorpb.FieldDescriptorProto_Type) string {
+	if typeName, ok := m.Params.ScalarTypeNames[protoType]; ok {
+		return typeName
+	}
+
+	switch protoType {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return graphql.ScalarString.TypeName()
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return graphql.ScalarBoolean.TypeName()
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64, descriptorpb.FieldDescriptorProto_TYPE_UINT64, descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64, descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		if m.Params.JS64BitType == parameters.JS64BitTypeString {
+			return graphql.ScalarString.TypeName()
+		}
+	}
+	return graphql.ScalarFloat.TypeName()
+}
+
 func (m *Mapper) buildOneofMapper(oneof *descriptor.Oneof, input bool) *OneofMapper {
-	oneofObjectName := oneof.Proto.GetName() + "Oneof"
-	unionTypeName := m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-		Namespace: oneof.Parent.File.Options.GetNamespace(),
-		Package:   oneof.Parent.Package,
-		TypeName:  append(oneof.Parent.TypeName, oneofObjectName),
-	})
+	unionTypeName := m.oneofTypeName(oneof, false)
 	parentProtoName := strings.TrimPrefix(oneof.Parent.FullName, ".")
-	mapper := &OneofMapper{
-		Descriptor: oneof,
-		Union: &graphql.Union{
+	description := fmt.Sprintf("`%s` represents the `%s` oneof in `%s`.", unionTypeName, oneof.Proto.GetName(), parentProtoName)
+	mapper := &OneofMapper{Descriptor: oneof}
+	if m.Params.OneofStyle == parameters.OneofStyleInterface {
+		mapper.Interface = &graphql.Interface{
 			Name:        unionTypeName,
-			Description: fmt.Sprintf("`%s` represents the `%s` oneof in `%s`.", unionTypeName, oneof.Proto.GetName(), parentProtoName),
-		},
+			Description: description,
+			Fields: []*graphql.Field{
+				{
+					Name:     "_typename",
+					TypeName: graphql.ScalarString.TypeName(),
+				},
+			},
+		}
+	} else {
+		mapper.Union = &graphql.Union{
+			Name:        unionTypeName,
+			Description: description,
+		}
+	}
+
+	// Count the members of each message type, as a message type can only be a
+	// member of a union once.
+	messageTypes := make(map[string]int)
+	for _, field := range oneof.Fields {
+		if field.Proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			messageTypes[field.Proto.GetTypeName()]++
+		}
 	}
 
 	for _, field := range oneof.Fields {
-		typeName := m.buildGraphqlTypeName(&GraphqlTypeNameParts{
+		if skipField(field, false) {
+			continue
+		}
+		memberField := m.graphqlField(field, false)
+
+		// With the union style, message members are members of the union
+		// themselves, unless they are mapped to a different type.
+		if mapper.Union != nil && m.Params.OneofStyle == parameters.OneofStyleUnion &&
+			field.Proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE &&
+			messageTypes[field.Proto.GetTypeName()] == 1 &&
+			memberField.TypeName == m.ObjectNames[field.Proto.GetTypeName()] {
+			mapper.Union.TypeNames = append(mapper.Union.TypeNames, memberField.TypeName)
+			continue
+		}
+
+		typeName := m.claimTypeName("oneof field "+parentProtoName+"."+field.Name, field.Location, m.buildGraphqlTypeName(&GraphqlTypeNameParts{
+			Kind:      TypeNameOneofMember,
 			Namespace: oneof.Parent.File.Options.GetNamespace(),
 			Package:   oneof.Parent.Package,
-			TypeName:  append(oneof.Parent.TypeName, oneofObjectName, field.Name),
-		})
+			TypeName:  oneof.Parent.TypeName,
+			Oneof:     oneof.Proto.GetName(),
+			Field:     field.Name,
+		}))
 
-		mapper.Union.TypeNames = append(mapper.Union.TypeNames, typeName)
-		mapper.Objects = append(mapper.Objects, &graphql.Object{
+		object := &graphql.Object{
 			Name:        typeName,
 			Description: fmt.Sprintf("`%s` represents the `%s` oneof field in `%s`.", typeName, field.Name, parentProtoName),
 			Fields: []*graphql.Field{
@@ -482,9 +722,15 @@ func (m *Mapper) buildOneofMapper(oneof *descriptor.Oneof, input bool) *OneofMap
 					Name:     "_typename",
 					TypeName: graphql.ScalarString.TypeName(),
 				},
-				m.graphqlField(field, false),
+				memberField,
 			},
-		})
+		}
+		if mapper.Interface != nil {
+			object.Interfaces = []string{mapper.Interface.Name}
+		} else {
+			mapper.Union.TypeNames = append(mapper.Union.TypeNames, typeName)
+		}
+		mapper.Objects = append(mapper.Objects, object)
 	}
 
 	if !input {
@@ -493,33 +739,76 @@ func (m *Mapper) buildOneofMapper(oneof *descriptor.Oneof, input bool) *OneofMap
 
 	var inputFields []*graphql.Field
 	for _, field := range oneof.Fields {
+		if skipField(field, true) {
+			continue
+		}
 		inputFields = append(inputFields, m.graphqlField(field, true))
 	}
 
 	mapper.Input = &graphql.Input{
-		Name: m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-			Namespace: oneof.Parent.File.Options.GetNamespace(),
-			Package:   oneof.Parent.Package,
-			TypeName:  append(oneof.Parent.TypeName, oneofObjectName),
-			Input:     true,
-		}),
+		Name:   m.oneofTypeName(oneof, true),
 		Fields: inputFields,
 	}
+	if m.Params.OneofDirective {
+		mapper.Input.Directives = append(mapper.Input.Directives, graphql.DirectiveOneOf)
+		m.validateOneofInput(mapper.Input, oneof.Location)
+	}
 
 	return mapper
 }
 
+// validateOneofInput validates that the fields of a @oneOf input type are all
+// nullable and have no default values. Errors are reported at the protobuf
+// field that an input field is mapped from, or otherwise at the location.
+func (m *Mapper) validateOneofInput(input *graphql.Input, location diagnostics.Location) {
+	for _, field := range input.Fields {
+		fieldLocation := location
+		if d, ok := m.FieldDescriptors[field]; ok {
+			fieldLocation = d.Location
+		}
+
+		nonNull := field.Modifiers&graphql.TypeModifierNonNull > 0
+		if field.Modifiers&graphql.TypeModifierList > 0 {
+			nonNull = field.Modifiers&graphql.TypeModifierNonNullList > 0
+		}
+		if nonNull || strings.HasSuffix(field.TypeName, "!") {
+			m.Diagnostics.Errorf(fieldLocation, "field %s of @oneOf input %s must be nullable", field.Name, input.Name)
+		}
+		if field.Default != "" {
+			m.Diagnostics.Errorf(fieldLocation, "field %s of @oneOf input %s must not have a default value", field.Name, input.Name)
+		}
+	}
+}
+
 func (m *Mapper) buildEnumMapper(enum *descriptor.Enum) {
 	var enumValues []*graphql.EnumValue
+	var zeroDropped bool
+	// Maps graphql enum value names and protobuf numbers to the values that
+	// they were mapped from.
+	names := make(map[string]*descriptor.EnumValue)
+	numbers := make(map[int32]bool)
 	for _, value := range enum.Values {
 		if value.Options.GetSkip() {
 			continue
 		}
 
-		valueName := value.Proto.GetName()
-		if value.Options.GetValue() != "" {
-			valueName = value.Options.GetValue()
+		// Aliases of a value that is already mapped are collapsed into it.
+		if numbers[value.Proto.GetNumber()] {
+			continue
+		}
+		numbers[value.Proto.GetNumber()] = true
+
+		valueName, ok := m.enumValueName(enum, value)
+		if !ok {
+			zeroDropped = true
+			continue
+		}
+		if other, ok := names[valueName]; ok {
+			m.Diagnostics.Errorf(value.Location, "enum values %s and %s of %s are both mapped to %s",
+				other.Proto.GetName(), value.Proto.GetName(), strings.TrimPrefix(enum.FullName, "."), valueName)
+			continue
 		}
+		names[valueName] = value
 
 		enumValue := &graphql.EnumValue{
 			Name:        valueName,
@@ -529,6 +818,7 @@ func (m *Mapper) buildEnumMapper(enum *descriptor.Enum) {
 		if value.Proto.Options.GetDeprecated() {
 			enumValue.Directives = append(enumValue.Directives, "deprecated")
 		}
+		m.EnumValueDescriptors[enumValue] = value
 		enumValues = append(enumValues, enumValue)
 	}
 
@@ -539,9 +829,37 @@ func (m *Mapper) buildEnumMapper(enum *descriptor.Enum) {
 			Description: enum.Comments,
 			Values:      enumValues,
 		},
+		ZeroDropped: zeroDropped,
 	}
 }
 
+// enumValueName returns the name of the graphql enum value that an enum value
+// is mapped to, or false if the value is dropped.
+func (m *Mapper) enumValueName(enum *descriptor.Enum, value *descriptor.EnumValue) (string, bool) {
+	if value.Options.GetValue() != "" {
+		return value.Options.GetValue(), true
+	}
+
+	valueName := value.Proto.GetName()
+	unspecified := valueName == "UNSPECIFIED" || strings.HasSuffix(valueName, "_UNSPECIFIED")
+	if value.Proto.GetNumber() == 0 && unspecified {
+		switch m.Params.EnumUnspecified {
+		case parameters.EnumUnspecifiedDrop:
+			// Enums must have at least one value.
+			if len(enum.Values) > 1 {
+				return "", false
+			}
+		case parameters.EnumUnspecifiedRename:
+			return m.Params.EnumUnspecifiedName, true
+		}
+	}
+
+	if m.Params.EnumTrimPrefix {
+		valueName = TrimEnumPrefix(enum.Proto.GetName(), valueName)
+	}
+	return m.EnumValueNameTransformer(valueName), true
+}
+
 func (m *Mapper) buildServiceMapper(service *descriptor.Service) {
 	var (
 		allMethods    = m.buildMethodsMapper(service, "")
@@ -554,9 +872,14 @@ func (m *Mapper) buildServiceMapper(service *descriptor.Service) {
 		return
 	}
 
+	// Methods whose operation could not be inferred.
+	var unclassified []string
 	for _, method := range service.Methods {
-		// Ignore streaming RPC methods.
-		if method.Proto.GetClientStreaming() || method.Proto.GetServerStreaming() {
+		streaming := method.Proto.GetClientStreaming() || method.Proto.GetServerStreaming()
+		if streaming && !subscribable(method) {
+			if method.Options.GetOperation() != "" {
+				m.warnUnsupportedStreaming(method)
+			}
 			continue
 		}
 
@@ -565,34 +888,58 @@ func (m *Mapper) buildServiceMapper(service *descriptor.Service) {
 		allMethods.Methods = append(allMethods.Methods, method)
 
 		if method.Options.GetSkip() {
-			fmt.Fprintf(
-				os.Stderr,
-				"%s: option (method.skip) for %s.%s is deprecated, methods now opt in with the 'operation' option\n",
-				service.File.Proto.GetName(),
+			m.Diagnostics.Warnf(
+				method.Location,
+				"option (method.skip) for %s.%s is deprecated, methods now opt in with the 'operation' option",
 				service.Proto.GetName(),
 				method.Proto.GetName(),
 			)
 			continue
 		}
 
-		switch operation := method.Options.GetOperation(); operation {
+		operation := method.Options.GetOperation()
+		if operation == "" && m.Params.InferOperations != parameters.InferOperationsNone {
+			operation = m.inferOperation(method)
+			if operation == "" {
+				unclassified = append(unclassified, method.Proto.GetName())
+			}
+		}
+
+		switch operation {
 		case "":
 			// No operation specified, ignore method.
 			continue
 		case "query":
+			if streaming {
+				m.warnStreamingOperation(method, operation)
+				continue
+			}
 			queries.Object.Fields = append(queries.Object.Fields, field)
 			queries.Methods = append(queries.Methods, method)
 		case "mutation":
+			if streaming {
+				m.warnStreamingOperation(method, operation)
+				continue
+			}
 			mutations.Object.Fields = append(mutations.Object.Fields, field)
 			mutations.Methods = append(mutations.Methods, method)
 		case "subscription":
 			subscriptions.Object.Fields = append(subscriptions.Object.Fields, field)
 			subscriptions.Methods = append(subscriptions.Methods, method)
 		default:
-			panic(fmt.Sprintf(`invalid operation: "%s" (expected "query", "mutation", or "subscription")`, operation))
+			m.Diagnostics.Errorf(method.Location, `invalid operation: "%s" (expected "query", "mutation", or "subscription")`, operation)
 		}
 	}
 
+	if len(unclassified) > 0 {
+		m.Diagnostics.Warnf(
+			service.Location,
+			"could not infer the operation of methods %s in %s, set option (graphql.method).operation to include them",
+			strings.Join(unclassified, ", "),
+			service.Proto.GetName(),
+		)
+	}
+
 	mapper := &ServiceMapper{
 		Descriptor:    service,
 		ReferenceName: m.referenceName(service),
@@ -600,45 +947,58 @@ func (m *Mapper) buildServiceMapper(service *descriptor.Service) {
 	}
 
 	if len(queries.Methods) > 0 {
-		queries.Object.Name = m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-			Namespace: service.File.Options.GetNamespace(),
-			Package:   service.Package,
-			TypeName:  append(service.TypeName, "Query"),
-		})
+		m.nameMethodsMapper(service, queries, "Query")
 		mapper.Queries = queries
 	}
 	if len(mutations.Methods) > 0 {
-		mutations.Object.Name = m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-			Namespace: service.File.Options.GetNamespace(),
-			Package:   service.Package,
-			TypeName:  append(service.TypeName, "Mutation"),
-		})
+		m.nameMethodsMapper(service, mutations, "Mutation")
 		mapper.Mutations = mutations
 	}
 	if len(subscriptions.Methods) > 0 {
-		subscriptions.Object.Name = m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-			Namespace: service.File.Options.GetNamespace(),
-			Package:   service.Package,
-			TypeName:  append(service.TypeName, "Subscription"),
-		})
+		m.nameMethodsMapper(service, subscriptions, "Subscription")
 		mapper.Subscriptions = subscriptions
 	}
 
 	m.ServiceMappers[service.FullName] = mapper
 }
 
+// subscribable returns whether a streaming method can be mapped to a
+// subscription field. The client must send exactly one request message, which
+// is the case for server streaming methods and for bidirectional streaming
+// methods that opt in with the 'single_request' option.
+func subscribable(method *descriptor.Method) bool {
+	if !method.Proto.GetClientStreaming() {
+		return true
+	}
+	return method.Proto.GetServerStreaming() && method.Options.GetSingleRequest()
+}
+
+func (m *Mapper) warnUnsupportedStreaming(method *descriptor.Method) {
+	m.Diagnostics.Warnf(
+		method.Location,
+		"streaming method %s.%s is not supported, only server streaming methods and bidirectional streaming methods with option (graphql.method).single_request can be mapped",
+		method.Service.Proto.GetName(),
+		method.Proto.GetName(),
+	)
+}
+
+func (m *Mapper) warnStreamingOperation(method *descriptor.Method, operation string) {
+	m.Diagnostics.Warnf(
+		method.Location,
+		"streaming method %s.%s can only be mapped to a subscription, got operation \"%s\"",
+		method.Service.Proto.GetName(),
+		method.Proto.GetName(),
+		operation,
+	)
+}
+
 func (m *Mapper) buildMethodsMapper(service *descriptor.Service, rootType string) *MethodsMapper {
 	var extends *graphql.ExtendObject
 	if m.Params.RootTypePrefix != nil {
 		extends = &graphql.ExtendObject{
 			Name: fmt.Sprintf("%s%s", *m.Params.RootTypePrefix, rootType),
 			Fields: []*graphql.Field{{
-				Name: m.FieldNameTransformer(m.referenceName(service)),
-				TypeName: m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-					Namespace: service.File.Options.GetNamespace(),
-					Package:   service.Package,
-					TypeName:  append(service.TypeName, rootType),
-				}),
+				Name:      m.FieldNameTransformer(m.referenceName(service)),
 				Modifiers: graphql.TypeModifierNonNull,
 			}},
 		}
@@ -652,11 +1012,48 @@ func (m *Mapper) buildMethodsMapper(service *descriptor.Service, rootType string
 	}
 }
 
+// nameMethodsMapper names the object type of a service's methods of a root
+// operation type, along with the field of the root type extension that
+// references it.
+func (m *Mapper) nameMethodsMapper(service *descriptor.Service, methods *MethodsMapper, rootType string) {
+	element := strings.ToLower(rootType) + " type of service " + strings.TrimPrefix(service.FullName, ".")
+	methods.Object.Name = m.claimTypeName(element, service.Location, m.buildGraphqlTypeName(&GraphqlTypeNameParts{
+		Kind:      TypeNameService,
+		Namespace: service.File.Options.GetNamespace(),
+		Package:   service.Package,
+		TypeName:  append(service.TypeName, rootType),
+	}))
+	if methods.ExtendRootObject != nil {
+		methods.ExtendRootObject.Fields[0].TypeName = methods.Object.Name
+	}
+}
+
 func (m *Mapper) graphqlFieldFromMethod(method *descriptor.Method) *graphql.Field {
-	// Only add an argument if there are fields in the gRPC request message.
+	var connection *ConnectionMapper
+	if method.Options.GetConnection() {
+		connection = m.buildConnectionMapper(method)
+	}
+
+	// Only add an argument if there are input fields in the gRPC request
+	// message, other than the pagination fields of a connection.
 	var arguments []*graphql.Argument
 	inputType := m.Messages[method.Proto.GetInputType()]
-	if len(inputType.Fields) != 0 {
+	if connection != nil {
+		arguments = connectionArguments()
+	}
+	var inputFields int
+	for _, field := range inputType.Fields {
+		if skipField(field, true) {
+			continue
+		}
+		if connection != nil && (field == connection.PageSizeField || field == connection.PageTokenField) {
+			continue
+		}
+		inputFields++
+	}
+	if inputFields != 0 && m.flattenArguments(method) {
+		arguments = append(arguments, m.flattenedArguments(inputType, connection)...)
+	} else if inputFields != 0 {
 		arguments = append(arguments, &graphql.Argument{
 			Name:      "input",
 			TypeName:  m.MessageMappers[method.Proto.GetInputType()].Input.Name,
@@ -676,45 +1073,109 @@ func (m *Mapper) graphqlFieldFromMethod(method *descriptor.Method) *graphql.Fiel
 		Arguments:   arguments,
 		Directives:  method.Options.GetDirective(),
 	}
+	if connection != nil {
+		field.TypeName = connection.Connection.Name
+	}
+	if path := method.Options.GetResponsePath(); path != "" && method.Options.GetConnection() {
+		m.Diagnostics.Errorf(method.Location, "method %s.%s can't have both the response_path and connection options",
+			method.Service.Proto.GetName(), method.Proto.GetName())
+	} else if path != "" {
+		if responseField := m.responsePathField(method, strings.Split(path, ".")); responseField != nil {
+			field.TypeName = responseField.TypeName
+			field.Modifiers = responseField.Modifiers
+		}
+	}
 	if method.Proto.Options.GetDeprecated() {
 		field.Directives = append(field.Directives, "deprecated")
 	}
 	return field
 }
 
+// Kinds of types that have their own type name template.
+type TypeNameKind int
+
+const (
+	TypeNameObject TypeNameKind = iota
+	TypeNameEnum
+	TypeNameOneof
+	TypeNameOneofMember
+	TypeNameMapEntry
+	TypeNameService
+)
+
 type GraphqlTypeNameParts struct {
-	Namespace  string
-	Package    string
-	TypeName   []string
-	IsProtoMap bool
-	Input      bool
+	Kind      TypeNameKind
+	Namespace string
+	Package   string
+	// Nested path of the type, or for oneofs and map entries, of their
+	// parent message.
+	TypeName []string
+	// Name of the oneof for oneofs and oneof members.
+	Oneof string
+	// Name of the oneof field for oneof members, and of the map field for map
+	// entries.
+	Field string
+	Input bool
 }
 
+// buildGraphqlTypeName builds a type name from the template for the kind of
+// type, then applies the 'trim_prefix' and 'type_name_rewrite' parameters.
+// Only the template for objects has an input variant, inputs of other kinds
+// have the input suffix appended.
 func (m *Mapper) buildGraphqlTypeName(parts *GraphqlTypeNameParts) string {
-	var b strings.Builder
-
-	if parts.Namespace != "" {
-		b.WriteString(parts.Namespace)
-	} else {
-		b.WriteString(CamelCaseSlice(strings.Split(parts.Package, ".")))
+	pkg := CamelCaseSlice(strings.Split(parts.Package, "."))
+	namespace := parts.Namespace
+	if namespace == "" {
+		namespace = pkg
+	}
+	var path []string
+	for _, name := range parts.TypeName {
+		path = append(path, CamelCase(name))
+	}
+	var name string
+	if len(path) > 0 {
+		name = path[len(path)-1]
 	}
 
-	for i, name := range parts.TypeName {
-		if parts.IsProtoMap && i == len(parts.TypeName)-1 {
-			name = strings.TrimSuffix(name, "Entry")
+	var template string
+	switch parts.Kind {
+	case TypeNameObject:
+		template = m.Params.ObjectNameTemplate
+		if parts.Input {
+			template = m.Params.InputNameTemplate
 		}
-
-		b.WriteString("_")
-		b.WriteString(CamelCase(name))
-	}
-	if parts.IsProtoMap {
-		b.WriteString("Entry")
+	case TypeNameEnum:
+		template = m.Params.EnumNameTemplate
+	case TypeNameOneof:
+		template = m.Params.OneofNameTemplate
+	case TypeNameOneofMember:
+		template = m.Params.OneofMemberNameTemplate
+	case TypeNameMapEntry:
+		template = m.Params.MapEntryNameTemplate
+	case TypeNameService:
+		// Root types of services are not configurable, so that they can't
+		// collide with the root operation types.
+		template = parameters.DefaultObjectNameTemplate
 	}
-	if parts.Input {
-		b.WriteString("Input")
+
+	typeName := strings.NewReplacer(
+		"{namespace}", namespace,
+		"{package}", pkg,
+		"{path}", strings.Join(path, "_"),
+		"{name}", name,
+		"{oneof}", CamelCase(parts.Oneof),
+		"{field}", CamelCase(parts.Field),
+		"{suffix}", m.Params.InputSuffix,
+	).Replace(template)
+	if parts.Input && parts.Kind != TypeNameObject {
+		typeName += m.Params.InputSuffix
 	}
 
-	return strings.TrimPrefix(b.String(), m.Params.TrimPrefix)
+	typeName = strings.TrimPrefix(typeName, m.Params.TrimPrefix)
+	for _, rewrite := range m.Params.TypeNameRewrites {
+		typeName = rewrite.Regexp.ReplaceAllString(typeName, rewrite.Replacement)
+	}
+	return typeName
 }
 
 func (m *Mapper) referenceName(service *descriptor.Service) string {
@@ -733,24 +1194,56 @@ func (m *Mapper) messageName(message *descriptor.Message, input bool) string {
 	if message.Options.GetType() != "" {
 		name := message.Options.GetType()
 		if input {
-			name += "Input"
+			name += m.Params.InputSuffix
 		}
 		return name
 	}
 
+	if message.IsMap {
+		// Map entries are named after their map field, e.g. LabelsEntry is
+		// the entry of the labels field.
+		n := len(message.TypeName)
+		return m.buildGraphqlTypeName(&GraphqlTypeNameParts{
+			Kind:      TypeNameMapEntry,
+			Namespace: message.File.Options.GetNamespace(),
+			Package:   message.Package,
+			TypeName:  message.TypeName[:n-1],
+			Field:     strings.TrimSuffix(message.TypeName[n-1], "Entry"),
+			Input:     input,
+		})
+	}
 	return m.buildGraphqlTypeName(&GraphqlTypeNameParts{
-		Namespace:  message.File.Options.GetNamespace(),
-		Package:    message.Package,
-		TypeName:   message.TypeName,
-		Input:      input,
-		IsProtoMap: message.IsMap,
+		Namespace: message.File.Options.GetNamespace(),
+		Package:   message.Package,
+		TypeName:  message.TypeName,
+		Input:     input,
 	})
 }
 
+// oneofTypeName returns the name of the union, interface or input type of a
+// oneof.
+func (m *Mapper) oneofTypeName(oneof *descriptor.Oneof, input bool) string {
+	element := "oneof " + strings.TrimPrefix(oneof.Parent.FullName, ".") + "." + oneof.Proto.GetName()
+	if input {
+		element = "input of " + element
+	}
+	return m.claimTypeName(element, oneof.Location, m.buildGraphqlTypeName(&GraphqlTypeNameParts{
+		Kind:      TypeNameOneof,
+		Namespace: oneof.Parent.File.Options.GetNamespace(),
+		Package:   oneof.Parent.Package,
+		TypeName:  oneof.Parent.TypeName,
+		Oneof:     oneof.Proto.GetName(),
+		Input:     input,
+	}))
+}
+
 func (m *Mapper) fieldName(field *descriptor.Field) string {
 	if field.Options.GetField() != "" {
 		return field.Options.GetField()
 	}
+	if m.Params.FieldName == parameters.FieldNameJSON && field.Proto.GetJsonName() != "" {
+		return field.Proto.GetJsonName()
+	}
 	return m.FieldNameTransformer(field.Name)
 }
 
@@ -761,6 +1254,7 @@ func (m *Mapper) enumName(enum *descriptor.Enum) string {
 
 	return m.buildGraphqlTypeName(&GraphqlTypeNameParts{
 		Namespace: enum.File.Options.GetNamespace(),
+		Kind:      TypeNameEnum,
 		Package:   enum.Package,
 		TypeName:  enum.TypeName,
 	})
@@ -770,9 +1264,5 @@ func (m *Mapper) nullableScalars(field *descriptor.Field, input bool) bool {
 	if input {
 		return true
 	}
-	switch field.Parent.File.Proto.GetSyntax() {
-	case "proto2", "":
-		return field.Proto.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
-	}
-	return false
+	return field.Presence == descriptorpb.FeatureSet_EXPLICIT
 }
@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+// isJSONMapEntry returns true if the message is the entry of a map that is
+// mapped to a JSON scalar, in which case no types are generated for it.
+// Only maps with string keys can be represented as JSON objects.
+func (m *Mapper) isJSONMapEntry(message *descriptor.Message) bool {
+	return m.Params.MapStyle == parameters.MapStyleJSON && message.IsMap &&
+		message.Fields[0].Proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING
+}
+
+// mapEntry returns the entry message of a map field, or nil if the field is
+// not a map.
+func (m *Mapper) mapEntry(field *descriptor.Field) *descriptor.Message {
+	if field.Proto.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		return nil
+	}
+	if message := m.Messages[field.Proto.GetTypeName()]; message.IsMap {
+		return message
+	}
+	return nil
+}
+
+// mapLookupField renames the entry list field of a map to '<field>Entries',
+// and returns a field that looks up the value of a single key in its place,
+// e.g. `labels(key: String!): String`.
+func (m *Mapper) mapLookupField(field *descriptor.Field, entries *graphql.Field) *graphql.Field {
+	entry := m.mapEntry(field)
+	keyField, valueField := entry.Fields[0], entry.Fields[1]
+
+	lookup := m.graphqlField(valueField, false)
+	lookup.Name = entries.Name
+	lookup.Directives = entries.Directives
+	// The value is null if the map does not have the key.
+	lookup.Modifiers &^= graphql.TypeModifierNonNull
+	lookup.Arguments = []*graphql.Argument{
+		{
+			Name:      "key",
+			TypeName:  m.scalarTypeName(keyField.Proto.GetType()),
+			Modifiers: graphql.TypeModifierNonNull,
+		},
+	}
+	m.FieldDescriptors[lookup] = field
+
+	if field.Options.GetField() != "" || m.Params.FieldName == parameters.FieldNameJSON {
+		entries.Name = m.fieldName(field) + "Entries"
+	} else {
+		entries.Name = m.FieldNameTransformer(field.Name + "_entries")
+	}
+	return lookup
+}
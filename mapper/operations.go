@@ -0,0 +1,155 @@
+package mapper
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+// Method name prefixes of the AIP standard methods that are queries. All
+// other methods, including custom methods, are mutations.
+var aipQueryPrefixes = []string{"Get", "List", "Search", "BatchGet"}
+
+// inferOperation returns the GraphQL operation of a method without an
+// 'operation' option, or an empty string if it can't be inferred. Streaming
+// methods are never inferred.
+//
+// With the 'http' mode, methods with a GET rule are queries and methods with
+// a POST, PUT, PATCH or DELETE rule are mutations.
+//
+// With the 'aip' mode, methods are classified by the prefixes of the AIP
+// standard methods, e.g. GetBook is a query and CreateBook is a mutation.
+func (m *Mapper) inferOperation(method *descriptor.Method) string {
+	if method.Proto.GetClientStreaming() || method.Proto.GetServerStreaming() {
+		return ""
+	}
+
+	if m.Params.InferOperations == parameters.InferOperationsAIP {
+		for _, prefix := range aipQueryPrefixes {
+			if hasAIPPrefix(method.Proto.GetName(), prefix) {
+				return "query"
+			}
+		}
+		return "mutation"
+	}
+
+	switch method.HTTPMethod {
+	case "GET":
+		return "query"
+	case "POST", "PUT", "PATCH", "DELETE":
+		return "mutation"
+	}
+	return ""
+}
+
+// hasAIPPrefix returns true if the method name starts with the verb, followed
+// by the name of a resource, e.g. "GetBook" has the prefix "Get" but "Getaway"
+// does not.
+func hasAIPPrefix(methodName, verb string) bool {
+	if !strings.HasPrefix(methodName, verb) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(methodName[len(verb):])
+	return unicode.IsUpper(r)
+}
+
+// buildAIPLoaders registers loaders for the AIP standard methods that load
+// resources by name, for messages that don't have a loader option. BatchGet
+// methods (AIP-231) are registered as 'load_many' loaders, and are preferred
+// over Get methods (AIP-131), which are registered as 'load_one' loaders.
+func (m *Mapper) buildAIPLoaders() {
+	for _, many := range []bool{true, false} {
+		for _, filePb := range m.FilePbs {
+			for _, service := range m.Files[filePb.GetName()].Services {
+				for _, method := range service.Methods {
+					if method.Options.GetLoadOne() != "" || method.Options.GetLoadMany() != "" {
+						continue
+					}
+
+					loader := m.aipLoader(method, many)
+					if loader == nil {
+						continue
+					}
+					if _, ok := m.Loaders[loader.FullName]; ok {
+						continue
+					}
+					m.Loaders[loader.FullName] = loader
+					method.Loaders = append(method.Loaders, loader)
+				}
+			}
+		}
+	}
+}
+
+// aipLoader returns a loader for a method if it follows the conventions of
+// the AIP-231 BatchGet method when many is true, or the AIP-131 Get method
+// otherwise, or nil if it does not.
+func (m *Mapper) aipLoader(method *descriptor.Method, many bool) *descriptor.Loader {
+	if method.Proto.GetClientStreaming() || method.Proto.GetServerStreaming() {
+		return nil
+	}
+	name := method.Proto.GetName()
+	request := m.Messages[method.Proto.GetInputType()]
+	response := m.Messages[method.Proto.GetOutputType()]
+
+	if !many {
+		// GetBook(GetBookRequest{name}) returns (Book).
+		if !hasAIPPrefix(name, "Get") || strings.TrimPrefix(name, "Get") != response.Proto.GetName() ||
+			!hasNameField(request, "name", false) {
+			return nil
+		}
+		return &descriptor.Loader{
+			FullName:         response.FullName,
+			RequestFieldPath: []string{"name"},
+			Method:           method,
+		}
+	}
+
+	// BatchGetBooks(BatchGetBooksRequest{names}) returns
+	// (BatchGetBooksResponse{books}).
+	if !hasAIPPrefix(name, "BatchGet") || !hasNameField(request, "names", true) {
+		return nil
+	}
+	var items *descriptor.Field
+	for _, field := range response.Fields {
+		if field.IsOneof ||
+			field.Proto.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED ||
+			field.Proto.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+			m.Messages[field.Proto.GetTypeName()].IsMap {
+			continue
+		}
+		if items != nil {
+			return nil
+		}
+		items = field
+	}
+	if items == nil || !hasNameField(m.Messages[items.Proto.GetTypeName()], "name", false) {
+		return nil
+	}
+	return &descriptor.Loader{
+		FullName:           items.Proto.GetTypeName(),
+		Many:               true,
+		RequestFieldPath:   []string{"names"},
+		ResponseFieldPath:  []string{items.Name},
+		ObjectKeyFieldPath: []string{"name"},
+		Method:             method,
+	}
+}
+
+// hasNameField returns true if the message has a string field with the given
+// name, which is repeated if and only if repeated is true.
+func hasNameField(message *descriptor.Message, name string, repeated bool) bool {
+	for _, field := range message.Fields {
+		if field.IsOneof || field.Name != name {
+			continue
+		}
+		return field.Proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING &&
+			(field.Proto.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) == repeated
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/martinxsliu/protoc-gen-graphql/descriptor"
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+)
+
+// responsePathField returns the graphql field of the field at the response
+// path of a method. The field is nullable, as the method may fail or any
+// message along the path may be unset, but the items of lists are non-null.
+// Returns nil if the path is invalid.
+func (m *Mapper) responsePathField(method *descriptor.Method, path []string) *graphql.Field {
+	methodName := fmt.Sprintf("%s.%s", method.Service.Proto.GetName(), method.Proto.GetName())
+	message := m.Messages[method.Proto.GetOutputType()]
+
+	var field *descriptor.Field
+	for i, name := range path {
+		if message == nil {
+			m.Diagnostics.Errorf(method.Location, "invalid response_path %s of %s: %s is not a message field",
+				strings.Join(path, "."), methodName, path[i-1])
+			return nil
+		}
+
+		field = messageField(message, name)
+		if field == nil {
+			m.Diagnostics.Errorf(method.Location, "invalid response_path %s of %s: unknown field %s in %s",
+				strings.Join(path, "."), methodName, name, strings.TrimPrefix(message.FullName, "."))
+			return nil
+		}
+
+		message = nil
+		if i < len(path)-1 {
+			if field.Proto.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+				m.Diagnostics.Errorf(method.Location, "invalid response_path %s of %s: %s is a repeated field",
+					strings.Join(path, "."), methodName, name)
+				return nil
+			}
+			if field.Proto.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				message = m.Messages[field.Proto.GetTypeName()]
+			}
+		}
+	}
+
+	gqlField := m.graphqlField(field, false)
+	if gqlField.Modifiers&graphql.TypeModifierList > 0 {
+		gqlField.Modifiers &^= graphql.TypeModifierNonNullList
+	} else {
+		gqlField.Modifiers &^= graphql.TypeModifierNonNull
+	}
+	return gqlField
+}
+
+// messageField returns the field of a message with the given name, including
+// the members of oneofs, or nil if there is none.
+func messageField(message *descriptor.Message, name string) *descriptor.Field {
+	for _, field := range message.Fields {
+		if !field.IsOneof && field.Name == name {
+			return field
+		}
+	}
+	for _, oneof := range message.Oneofs {
+		for _, field := range oneof.Fields {
+			if field.Name == name {
+				return field
+			}
+		}
+	}
+	return nil
+}
@@ -18,6 +18,48 @@ func UpperCamelToLowerCamelTransformer(name string) string {
 	return ToLowerCamel(ParseUpperCamel(name))
 }
 
+// JSONNameTransformer transforms a string from lower_underscore format to the
+// format of the json_name that protoc derives for fields, which only upper
+// cases the letters following underscores, e.g. user_id becomes userId.
+func JSONNameTransformer(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SnakeCaseTransformer transforms a string in any casing to lower_snake_case,
+// e.g. GetHTTPRule becomes get_http_rule.
+func SnakeCaseTransformer(name string) string {
+	return strings.Join(ParseWords(name), "_")
+}
+
+// UpperSnakeCaseTransformer transforms a string in any casing to
+// UPPER_SNAKE_CASE.
+func UpperSnakeCaseTransformer(name string) string {
+	return strings.ToUpper(SnakeCaseTransformer(name))
+}
+
+// UpperCamelTransformer transforms a string in any casing to UpperCamelCase.
+func UpperCamelTransformer(name string) string {
+	return ToUpperCamel(ParseWords(name))
+}
+
+// LowerCamelTransformer transforms a string in any casing to lowerCamelCase.
+func LowerCamelTransformer(name string) string {
+	return ToLowerCamel(ParseWords(name))
+}
+
 // PreserveTransformer is a no-op.
 func PreserveTransformer(name string) string {
 	return name
@@ -102,6 +144,70 @@ func ParseUpperCamel(input string) []string {
 	return words
 }
 
+// ParseWords splits a string in any casing into lower cased words, which are
+// separated by underscores or start at an upper case letter that follows a
+// lower case letter or digit. An upper case letter followed by a lower case
+// letter also starts a word after a run of upper case letters, so acronyms
+// are kept together, e.g. GetHTTPRule becomes get, http and rule.
+func ParseWords(input string) []string {
+	var words []string
+	var running []rune
+	runes := []rune(input)
+	for i, r := range runes {
+		if r == '_' {
+			if len(running) != 0 {
+				words = append(words, string(running))
+				running = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(running) != 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(running))
+				running = nil
+			}
+		}
+		running = append(running, unicode.ToLower(r))
+	}
+	if len(running) != 0 {
+		words = append(words, string(running))
+	}
+	return words
+}
+
+// TrimEnumPrefix trims the upper snake case form of an enum's name from the
+// start of one of its value names, ignoring case and underscores, e.g. the
+// value HTTP_METHOD_GET of both HttpMethod and HTTPMethod becomes GET.
+// The name is returned as-is if the rest would not be a valid GraphQL name.
+func TrimEnumPrefix(enumName, valueName string) string {
+	i := 0
+	for _, r := range strings.ToLower(enumName) {
+		if r == '_' {
+			continue
+		}
+		for i < len(valueName) && valueName[i] == '_' {
+			i++
+		}
+		if i == len(valueName) || unicode.ToLower(rune(valueName[i])) != r {
+			return valueName
+		}
+		i++
+	}
+
+	// The prefix must be followed by an underscore, e.g. ROLEX is not trimmed
+	// in Role.
+	if i == len(valueName) || valueName[i] != '_' {
+		return valueName
+	}
+	trimmed := strings.TrimLeft(valueName[i:], "_")
+	if trimmed == "" || isASCIIDigit(trimmed[0]) {
+		return valueName
+	}
+	return trimmed
+}
+
 // CamelCaseSlice is like CamelCase, but the argument is a slice of strings to
 // be joined with "_".
 func CamelCaseSlice(elem []string) string { return CamelCase(strings.Join(elem, "_")) }
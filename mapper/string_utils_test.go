@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -37,3 +38,71 @@ func TestUpperCaseFirstRune(t *testing.T) {
 		}
 	}
 }
+
+func TestParseWords(t *testing.T) {
+	var testCases = []struct {
+		in  string
+		out []string
+	}{
+		{"user_id", []string{"user", "id"}},
+		{"USER_ID", []string{"user", "id"}},
+		{"userId", []string{"user", "id"}},
+		{"GetUser", []string{"get", "user"}},
+		{"GetHTTPRule", []string{"get", "http", "rule"}},
+		{"HTTPS", []string{"https"}},
+		{"address_2", []string{"address", "2"}},
+		{"address2Line", []string{"address2", "line"}},
+		{"__typename", []string{"typename"}},
+	}
+	for _, testCase := range testCases {
+		words := ParseWords(testCase.in)
+		if strings.Join(words, ",") != strings.Join(testCase.out, ",") {
+			t.Errorf("got %v; want %v", words, testCase.out)
+		}
+	}
+}
+
+func TestNameTransformers(t *testing.T) {
+	var testCases = []struct {
+		transformer func(string) string
+		in, out     string
+	}{
+		{JSONNameTransformer, "user_id", "userId"},
+		{JSONNameTransformer, "user_ID", "userID"},
+		{JSONNameTransformer, "address_2", "address2"},
+		{SnakeCaseTransformer, "userId", "user_id"},
+		{SnakeCaseTransformer, "GetHTTPRule", "get_http_rule"},
+		{UpperSnakeCaseTransformer, "statusActive", "STATUS_ACTIVE"},
+		{UpperCamelTransformer, "user_id", "UserId"},
+		{UpperCamelTransformer, "STATUS_ACTIVE", "StatusActive"},
+		{LowerCamelTransformer, "STATUS_ACTIVE", "statusActive"},
+		{LowerCamelTransformer, "GetHTTPRule", "getHttpRule"},
+	}
+	for _, testCase := range testCases {
+		s := testCase.transformer(testCase.in)
+		if s != testCase.out {
+			t.Errorf("got %s; want %s", s, testCase.out)
+		}
+	}
+}
+
+func TestTrimEnumPrefix(t *testing.T) {
+	var testCases = []struct{ enum, in, out string }{
+		{"Role", "ROLE_ADMIN", "ADMIN"},
+		{"Role", "ROLE_UNSPECIFIED", "UNSPECIFIED"},
+		{"Role", "ADMIN", "ADMIN"},
+		{"Role", "ROLEX", "ROLEX"},
+		{"Role", "ROLE_", "ROLE_"},
+		{"Role", "ROLE_1", "ROLE_1"},
+		{"UserRole", "USER_ROLE_ADMIN", "ADMIN"},
+		{"HTTPMethod", "HTTP_METHOD_GET", "GET"},
+		{"HttpMethod", "HTTPMETHOD_GET", "GET"},
+		{"Role", "role_admin", "admin"},
+	}
+	for _, testCase := range testCases {
+		s := TrimEnumPrefix(testCase.enum, testCase.in)
+		if s != testCase.out {
+			t.Errorf("got %s; want %s", s, testCase.out)
+		}
+	}
+}
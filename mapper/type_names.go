@@ -0,0 +1,76 @@
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/martinxsliu/protoc-gen-graphql/diagnostics"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+// TypeNameOwner is a protobuf element that a GraphQL type name is mapped
+// from.
+type TypeNameOwner struct {
+	// Description of the element, e.g. "message my.package.User" or "input of
+	// oneof my.package.User.id".
+	Element  string
+	Location diagnostics.Location
+}
+
+func (o *TypeNameOwner) String() string {
+	if o.Location.File == "" {
+		return o.Element
+	}
+	return fmt.Sprintf("%s at %s", o.Element, o.Location)
+}
+
+// Names of the types that Apollo Federation defines in subgraph schemas.
+var federationTypeNames = []string{"_Any", "_Entity", "_Service"}
+
+// claimTypeName claims a GraphQL type name for a protobuf element, and returns
+// the name that the element is mapped to. Claiming a name for the same element
+// again returns the same name.
+//
+// If the name is already claimed by another element, the collision is an
+// error. With the 'type_name_collisions=disambiguate' parameter, the name is
+// suffixed with the lowest number from 2 that makes it unique instead, and
+// the renaming is reported as a warning. Elements claim names in the order
+// that they are mapped, so types of messages and enums keep their names over
+// the types of oneofs, services and connections. The federation types are
+// claimed first, as their names can't change.
+func (m *Mapper) claimTypeName(element string, location diagnostics.Location, name string) string {
+	if claimed, ok := m.ClaimedTypeNames[element]; ok {
+		return claimed
+	}
+
+	claimed := name
+	if owner, ok := m.TypeNameOwners[name]; ok {
+		if m.Params.TypeNameCollisions == parameters.TypeNameCollisionsDisambiguate {
+			for i := 2; ; i++ {
+				claimed = fmt.Sprintf("%s%d", name, i)
+				if _, ok := m.TypeNameOwners[claimed]; !ok {
+					break
+				}
+			}
+			m.Diagnostics.Warnf(location, "GraphQL type name %s of %s collides with %s, renamed to %s",
+				name, element, owner, claimed)
+		} else {
+			m.Diagnostics.Errorf(location, "GraphQL type name %s of %s collides with %s",
+				name, element, owner)
+		}
+	}
+
+	if _, ok := m.TypeNameOwners[claimed]; !ok {
+		m.TypeNameOwners[claimed] = &TypeNameOwner{Element: element, Location: location}
+	}
+	m.ClaimedTypeNames[element] = claimed
+	return claimed
+}
+
+// claimFederationTypeNames claims the names of the Apollo Federation types
+// before any other types are mapped, as the names are fixed by the
+// specification.
+func (m *Mapper) claimFederationTypeNames() {
+	for _, name := range federationTypeNames {
+		m.claimTypeName("federation type "+name, diagnostics.Location{}, name)
+	}
+}
@@ -2,7 +2,10 @@ package parameters
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 const (
@@ -10,13 +13,90 @@ const (
 	InputModeService = "service"
 	InputModeAll     = "all"
 
-	FieldNameDefault  = "lower_camel_case"
-	FieldNamePreserve = "preserve"
+	FieldNameDefault        = "lower_camel_case"
+	FieldNamePreserve       = "preserve"
+	FieldNameJSON           = "json"
+	FieldNameSnakeCase      = "snake_case"
+	FieldNameUpperCamelCase = "upper_camel_case"
+
+	EnumValueNamePreserve       = "preserve"
+	EnumValueNameUpperSnakeCase = "upper_snake_case"
+	EnumValueNameLowerCamelCase = "lower_camel_case"
+	EnumValueNameUpperCamelCase = "upper_camel_case"
+	EnumValueNameSnakeCase      = "snake_case"
 
 	JS64BitTypeString = "string"
 	JS64BitTypeNumber = "number"
+
+	LoadersNone = ""
+	LoadersGo   = "go"
+
+	DefaultScalarsFileName = "scalars_pb.graphql"
+
+	LayoutPerFile    = "per_file"
+	LayoutPerPackage = "per_package"
+	LayoutPerService = "per_service"
+	LayoutSingle     = "single"
+
+	DefaultSingleFileName = "schema.graphql"
+
+	DefaultManifestFileName = "manifest.json"
+
+	FederationNone = ""
+	FederationV2   = "2"
+
+	OneofStyleWrapper   = "wrapper"
+	OneofStyleUnion     = "union"
+	OneofStyleInterface = "interface"
+	OneofStyleFlatten   = "flatten"
+
+	MapStyleNone = ""
+	MapStyleList = "list"
+	MapStyleJSON = "json"
+
+	DefaultMapScalarName = "JSON"
+
+	InferOperationsNone = ""
+	InferOperationsHTTP = "http"
+	InferOperationsAIP  = "aip"
+
+	EnumUnspecifiedKeep   = ""
+	EnumUnspecifiedDrop   = "drop"
+	EnumUnspecifiedRename = "rename"
+
+	TypeNameCollisionsError        = "error"
+	TypeNameCollisionsDisambiguate = "disambiguate"
+
+	DefaultObjectNameTemplate      = "{namespace}_{path}"
+	DefaultInputNameTemplate       = "{namespace}_{path}{suffix}"
+	DefaultEnumNameTemplate        = "{namespace}_{path}"
+	DefaultOneofNameTemplate       = "{namespace}_{path}_{oneof}Oneof"
+	DefaultOneofMemberNameTemplate = "{namespace}_{path}_{oneof}Oneof_{field}"
+	DefaultMapEntryNameTemplate    = "{namespace}_{path}_{field}Entry"
+
+	DefaultInputSuffix = "Input"
 )
 
+var templatePlaceholderRegexp = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// Placeholders that each type name template can contain. For oneofs and map
+// entries, the path and name placeholders are those of the parent message.
+var templatePlaceholders = map[string][]string{
+	"object_name_template":       {"namespace", "package", "path", "name"},
+	"input_name_template":        {"namespace", "package", "path", "name", "suffix"},
+	"enum_name_template":         {"namespace", "package", "path", "name"},
+	"oneof_name_template":        {"namespace", "package", "path", "name", "oneof"},
+	"oneof_member_name_template": {"namespace", "package", "path", "name", "oneof", "field"},
+	"map_entry_name_template":    {"namespace", "package", "path", "name", "field"},
+}
+
+// TypeNameRewrite is a rule that rewrites generated type names, replacing
+// matches of Regexp with Replacement.
+type TypeNameRewrite struct {
+	Regexp      *regexp.Regexp
+	Replacement string
+}
+
 type Parameters struct {
 	TimestampTypeName string
 	DurationTypeName  string
@@ -26,12 +106,81 @@ type Parameters struct {
 	JS64BitType       string
 	RootTypePrefix    *string
 	FieldName         string
+	// Casing of the field names that methods are mapped to. Uses the casing
+	// of field_name by default.
+	MethodName string
+	// Casing of enum value names.
+	EnumValueName     string
 	TrimPrefix        string
 	NullableListTypes bool
+	Loaders           string
+	// Maps protobuf scalar types to the GraphQL scalar type names they are
+	// mapped to, overriding the defaults.
+	ScalarTypeNames map[descriptorpb.FieldDescriptorProto_Type]string
+	// If set, definitions of the custom scalars referenced by the generated
+	// types are output to this file.
+	ScalarsFileName string
+	// Maps custom scalar names to the URLs of their specifications.
+	SpecifiedByURLs map[string]string
+	// Names of custom scalars that field type options can reference.
+	CustomScalars []string
+	// Determines how the generated types are grouped into output files.
+	Layout string
+	// Name of the output file for the single layout.
+	SingleFileName string
+	// If set, a JSON manifest that maps the generated types and fields to
+	// their protobuf descriptors is output to this file.
+	ManifestFileName string
+	// Version of Apollo Federation to generate a subgraph schema for.
+	Federation string
+	// If true, oneof input types have the @oneOf directive.
+	OneofDirective bool
+	// Determines how oneofs are mapped to GraphQL types.
+	OneofStyle string
+	// Determines how maps with string keys are mapped to GraphQL types.
+	MapStyle string
+	// Name of the scalar that maps are mapped to for the json map style.
+	MapScalarName string
+	// If true, the upper snake case enum name is trimmed from the start of
+	// enum value names.
+	EnumTrimPrefix bool
+	// Determines what happens to the zero enum values named *_UNSPECIFIED.
+	EnumUnspecified string
+	// Name of the zero enum values for the rename enum_unspecified mode.
+	EnumUnspecifiedName string
+	// If true, input fields with implicit presence have their zero value as
+	// their default value.
+	ZeroDefaults bool
+	// Determines how the operations of methods without an 'operation' option
+	// are inferred.
+	InferOperations string
+	// If true, the fields of request messages are mapped to separate
+	// arguments instead of a single input argument.
+	FlattenArguments bool
+	// Determines what happens when protobuf elements are mapped to the same
+	// GraphQL type name.
+	TypeNameCollisions string
+	// Templates that the names of the types mapped from messages, enums,
+	// oneofs and map entries are built from.
+	ObjectNameTemplate      string
+	InputNameTemplate       string
+	EnumNameTemplate        string
+	OneofNameTemplate       string
+	OneofMemberNameTemplate string
+	MapEntryNameTemplate    string
+	// Suffix of the names of input types.
+	InputSuffix string
+	// Rules that rewrite generated type names, applied in order after the
+	// 'trim_prefix' parameter.
+	TypeNameRewrites []*TypeNameRewrite
 }
 
 func NewParameters(parameter string) (*Parameters, error) {
-	params := &Parameters{}
+	params := &Parameters{
+		ScalarTypeNames: make(map[descriptorpb.FieldDescriptorProto_Type]string),
+		SpecifiedByURLs: make(map[string]string),
+		InputSuffix:     DefaultInputSuffix,
+	}
 
 	parts := strings.Split(parameter, ",")
 	for _, part := range parts {
@@ -66,6 +215,84 @@ func NewParameters(parameter string) (*Parameters, error) {
 			params.WrappersAsNull = true
 		case "nullable_list_types":
 			params.NullableListTypes = true
+		case "oneof_directive":
+			params.OneofDirective = true
+		case "oneof_style":
+			switch value {
+			case OneofStyleWrapper, OneofStyleUnion, OneofStyleInterface, OneofStyleFlatten:
+			default:
+				return nil, fmt.Errorf(`invalid value for oneof_style: "%s" (expected "wrapper", "union", "interface" or "flatten")`, value)
+			}
+			params.OneofStyle = value
+		case "map_style":
+			mapStyle := strings.SplitN(value, "=", 2)
+			switch mapStyle[0] {
+			case MapStyleList:
+			case MapStyleJSON:
+				params.MapScalarName = DefaultMapScalarName
+				if len(mapStyle) == 2 && mapStyle[1] != "" {
+					params.MapScalarName = mapStyle[1]
+				}
+			default:
+				return nil, fmt.Errorf(`invalid value for map_style: "%s" (expected "list" or "json=<scalar>")`, value)
+			}
+			params.MapStyle = mapStyle[0]
+		case "infer_operations":
+			if value != InferOperationsHTTP && value != InferOperationsAIP {
+				return nil, fmt.Errorf(`invalid value for infer_operations: "%s" (expected "http" or "aip")`, value)
+			}
+			params.InferOperations = value
+		case "flatten_arguments":
+			params.FlattenArguments = true
+		case "zero_defaults":
+			params.ZeroDefaults = true
+		case "enum_trim_prefix":
+			params.EnumTrimPrefix = true
+		case "enum_unspecified":
+			unspecified := strings.SplitN(value, "=", 2)
+			switch unspecified[0] {
+			case EnumUnspecifiedDrop:
+			case EnumUnspecifiedRename:
+				if len(unspecified) != 2 || unspecified[1] == "" {
+					return nil, fmt.Errorf("missing name for enum_unspecified=rename")
+				}
+				params.EnumUnspecifiedName = unspecified[1]
+			default:
+				return nil, fmt.Errorf(`invalid value for enum_unspecified: "%s" (expected "drop" or "rename=<name>")`, value)
+			}
+			params.EnumUnspecified = unspecified[0]
+		case "type_name_collisions":
+			if value != TypeNameCollisionsError && value != TypeNameCollisionsDisambiguate {
+				return nil, fmt.Errorf(`invalid value for type_name_collisions: "%s" (expected "error" or "disambiguate")`, value)
+			}
+			params.TypeNameCollisions = value
+		case "object_name_template", "input_name_template", "enum_name_template",
+			"oneof_name_template", "oneof_member_name_template", "map_entry_name_template":
+			if err := validateTemplate(key, value); err != nil {
+				return nil, err
+			}
+			switch key {
+			case "object_name_template":
+				params.ObjectNameTemplate = value
+			case "input_name_template":
+				params.InputNameTemplate = value
+			case "enum_name_template":
+				params.EnumNameTemplate = value
+			case "oneof_name_template":
+				params.OneofNameTemplate = value
+			case "oneof_member_name_template":
+				params.OneofMemberNameTemplate = value
+			case "map_entry_name_template":
+				params.MapEntryNameTemplate = value
+			}
+		case "input_suffix":
+			params.InputSuffix = value
+		case "type_name_rewrite":
+			rewrite, err := parseTypeNameRewrite(value)
+			if err != nil {
+				return nil, err
+			}
+			params.TypeNameRewrites = append(params.TypeNameRewrites, rewrite)
 		case "input_mode":
 			params.InputMode = value
 		case "js_64bit_type":
@@ -73,18 +300,184 @@ func NewParameters(parameter string) (*Parameters, error) {
 		case "root_type_prefix":
 			params.RootTypePrefix = &value
 		case "field_name":
-			if value != FieldNamePreserve {
-				value = FieldNameDefault
+			switch value {
+			case FieldNameDefault, FieldNamePreserve, FieldNameJSON, FieldNameSnakeCase, FieldNameUpperCamelCase:
+			default:
+				return nil, fmt.Errorf(`invalid value for field_name: "%s" (expected "lower_camel_case", "preserve", "json", "snake_case" or "upper_camel_case")`, value)
 			}
 			params.FieldName = value
+		case "method_name":
+			switch value {
+			case FieldNameDefault, FieldNamePreserve, FieldNameSnakeCase, FieldNameUpperCamelCase:
+			default:
+				return nil, fmt.Errorf(`invalid value for method_name: "%s" (expected "lower_camel_case", "preserve", "snake_case" or "upper_camel_case")`, value)
+			}
+			params.MethodName = value
+		case "enum_value_name":
+			switch value {
+			case EnumValueNamePreserve, EnumValueNameUpperSnakeCase, EnumValueNameLowerCamelCase,
+				EnumValueNameUpperCamelCase, EnumValueNameSnakeCase:
+			default:
+				return nil, fmt.Errorf(`invalid value for enum_value_name: "%s" (expected "preserve", "upper_snake_case", "lower_camel_case", "upper_camel_case" or "snake_case")`, value)
+			}
+			params.EnumValueName = value
 		case "trim_prefix":
 			params.TrimPrefix = value
+		case "loaders":
+			if value != LoadersGo {
+				return nil, fmt.Errorf(`invalid value for loaders: "%s" (expected "go")`, value)
+			}
+			params.Loaders = value
+		case "scalar":
+			protoType, typeName, err := parseScalar(value)
+			if err != nil {
+				return nil, err
+			}
+			params.ScalarTypeNames[protoType] = typeName
+		case "scalars_file":
+			if value == "" {
+				value = DefaultScalarsFileName
+			}
+			params.ScalarsFileName = value
+		case "custom_scalar":
+			if value == "" {
+				return nil, fmt.Errorf("missing name for custom_scalar")
+			}
+			params.CustomScalars = append(params.CustomScalars, value)
+		case "specified_by":
+			parts := strings.SplitN(value, ":", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return nil, fmt.Errorf(`invalid value for specified_by: "%s" (expected "<scalar>:<url>")`, value)
+			}
+			params.SpecifiedByURLs[parts[0]] = parts[1]
+		case "layout":
+			layout := strings.SplitN(value, "=", 2)
+			switch layout[0] {
+			case LayoutPerFile, LayoutPerPackage, LayoutPerService:
+			case LayoutSingle:
+				params.SingleFileName = DefaultSingleFileName
+				if len(layout) == 2 && layout[1] != "" {
+					params.SingleFileName = layout[1]
+				}
+			default:
+				return nil, fmt.Errorf(`invalid value for layout: "%s" (expected "per_file", "per_package", "per_service" or "single=<name>")`, value)
+			}
+			params.Layout = layout[0]
+		case "manifest":
+			if value == "" {
+				value = DefaultManifestFileName
+			}
+			params.ManifestFileName = value
+		case "federation":
+			if value != FederationV2 {
+				return nil, fmt.Errorf(`invalid value for federation: "%s" (expected "2")`, value)
+			}
+			params.Federation = value
 		}
 	}
 
 	if params.InputMode == "" {
 		params.InputMode = InputModeService
 	}
+	if params.FieldName == "" {
+		params.FieldName = FieldNameDefault
+	}
+	if params.MethodName == "" {
+		// There are no json names for methods, so they are lower camel cased
+		// like the json names of fields.
+		params.MethodName = params.FieldName
+		if params.MethodName == FieldNameJSON {
+			params.MethodName = FieldNameDefault
+		}
+	}
+	if params.EnumValueName == "" {
+		params.EnumValueName = EnumValueNamePreserve
+	}
+	if params.OneofStyle == "" {
+		params.OneofStyle = OneofStyleWrapper
+	}
+	if params.Layout == "" {
+		params.Layout = LayoutPerFile
+	}
+	if params.TypeNameCollisions == "" {
+		params.TypeNameCollisions = TypeNameCollisionsError
+	}
+	if params.ObjectNameTemplate == "" {
+		params.ObjectNameTemplate = DefaultObjectNameTemplate
+	}
+	if params.InputNameTemplate == "" {
+		params.InputNameTemplate = DefaultInputNameTemplate
+	}
+	if params.EnumNameTemplate == "" {
+		params.EnumNameTemplate = DefaultEnumNameTemplate
+	}
+	if params.OneofNameTemplate == "" {
+		params.OneofNameTemplate = DefaultOneofNameTemplate
+	}
+	if params.OneofMemberNameTemplate == "" {
+		params.OneofMemberNameTemplate = DefaultOneofMemberNameTemplate
+	}
+	if params.MapEntryNameTemplate == "" {
+		params.MapEntryNameTemplate = DefaultMapEntryNameTemplate
+	}
 
 	return params, nil
 }
+
+// parseScalar parses a scalar mapping of the form "<protobuf type>:<graphql
+// type>", e.g. "int64:Long".
+func parseScalar(value string) (descriptorpb.FieldDescriptorProto_Type, string, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return 0, "", fmt.Errorf(`invalid value for scalar: "%s" (expected "<protobuf type>:<graphql type>")`, value)
+	}
+
+	protoType, ok := descriptorpb.FieldDescriptorProto_Type_value["TYPE_"+strings.ToUpper(parts[0])]
+	switch descriptorpb.FieldDescriptorProto_Type(protoType) {
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		ok = false
+	}
+	if !ok {
+		return 0, "", fmt.Errorf(`invalid protobuf scalar type for scalar: "%s"`, parts[0])
+	}
+
+	return descriptorpb.FieldDescriptorProto_Type(protoType), parts[1], nil
+}
+
+// validateTemplate validates that a type name template is not empty and only
+// contains the placeholders supported by the template parameter key.
+func validateTemplate(key, value string) error {
+	if value == "" {
+		return fmt.Errorf("missing template for %s", key)
+	}
+	for _, match := range templatePlaceholderRegexp.FindAllStringSubmatch(value, -1) {
+		supported := false
+		for _, placeholder := range templatePlaceholders[key] {
+			if match[1] == placeholder {
+				supported = true
+			}
+		}
+		if !supported {
+			return fmt.Errorf(`invalid placeholder for %s: "%s" (expected one of {%s})`,
+				key, match[0], strings.Join(templatePlaceholders[key], "}, {"))
+		}
+	}
+	return nil
+}
+
+// parseTypeNameRewrite parses a type name rewrite rule of the form
+// "<regexp>:<replacement>", e.g. "^MyPackage_:". The rule is split at the last
+// colon, as type names can't contain colons but regular expressions can.
+func parseTypeNameRewrite(value string) (*TypeNameRewrite, error) {
+	i := strings.LastIndex(value, ":")
+	if i <= 0 {
+		return nil, fmt.Errorf(`invalid value for type_name_rewrite: "%s" (expected "<regexp>:<replacement>")`, value)
+	}
+
+	re, err := regexp.Compile(value[:i])
+	if err != nil {
+		return nil, fmt.Errorf("invalid regexp for type_name_rewrite: %v", err)
+	}
+	return &TypeNameRewrite{Regexp: re, Replacement: value[i+1:]}, nil
+}
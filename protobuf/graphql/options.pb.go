@@ -61,7 +61,22 @@ func (m *FileOptions) GetNamespace() string {
 
 type MessageOptions struct {
 	// Name of the generated GraphQL type.
-	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Apollo Federation keys of the generated GraphQL object, only used with
+	// the 'federation' parameter. Each key is a selection set of the object's
+	// GraphQL fields, for example:
+	//
+	//   option (graphql.message) = {
+	//     key: "id"
+	//     key: "organization { id } name"
+	//   };
+	//
+	// If unset, a key is derived from the object key field path of the
+	// message's 'load_many' loader, if it has one.
+	Key []string `protobuf:"bytes,2,rep,name=key,proto3" json:"key,omitempty"`
+	// Mark the generated GraphQL object as @shareable, only used with the
+	// 'federation' parameter.
+	Shareable            bool     `protobuf:"varint,3,opt,name=shareable,proto3" json:"shareable,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -98,6 +113,20 @@ func (m *MessageOptions) GetType() string {
 	return ""
 }
 
+func (m *MessageOptions) GetKey() []string {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *MessageOptions) GetShareable() bool {
+	if m != nil {
+		return m.Shareable
+	}
+	return false
+}
+
 type FieldOptions struct {
 	// Name of the field in the generated GraphQL object and input types.
 	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
@@ -133,7 +162,17 @@ type FieldOptions struct {
 	//   addressId: String!
 	//   address: MyPackage_Address
 	// }
-	ForeignKey           string   `protobuf:"bytes,5,opt,name=foreign_key,json=foreignKey,proto3" json:"foreign_key,omitempty"`
+	ForeignKey string `protobuf:"bytes,5,opt,name=foreign_key,json=foreignKey,proto3" json:"foreign_key,omitempty"`
+	// Skip this field from being generated in input types, e.g. for fields
+	// that are only set by the server. Equivalent to the OUTPUT_ONLY
+	// google.api.field_behavior annotation.
+	SkipInput bool `protobuf:"varint,7,opt,name=skip_input,json=skipInput,proto3" json:"skip_input,omitempty"`
+	// Skip this field from being generated in object types. Equivalent to the
+	// INPUT_ONLY google.api.field_behavior annotation.
+	SkipOutput bool `protobuf:"varint,8,opt,name=skip_output,json=skipOutput,proto3" json:"skip_output,omitempty"`
+	// Mark the field as non-null in input types. Equivalent to the REQUIRED
+	// google.api.field_behavior annotation.
+	Required             bool     `protobuf:"varint,9,opt,name=required,proto3" json:"required,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -205,6 +244,27 @@ func (m *FieldOptions) GetForeignKey() string {
 	return ""
 }
 
+func (m *FieldOptions) GetSkipInput() bool {
+	if m != nil {
+		return m.SkipInput
+	}
+	return false
+}
+
+func (m *FieldOptions) GetSkipOutput() bool {
+	if m != nil {
+		return m.SkipOutput
+	}
+	return false
+}
+
+func (m *FieldOptions) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
 type EnumOptions struct {
 	// Name of the generated GraphQL type.
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -359,6 +419,10 @@ type MethodOptions struct {
 	// Type of GraphQL operation. Valid values are "query", "mutation", or
 	// "subscription". A value must be specified for the method to be included
 	// in the generated type.
+	//
+	// Server streaming methods can only be mapped to subscriptions. Client
+	// streaming methods are not supported, except for bidirectional streaming
+	// methods that set 'single_request'.
 	Operation string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
 	// Mark the gRPC method as one that can load a single instance of a Protobuf
 	// message from a key identifier.
@@ -417,6 +481,53 @@ type MethodOptions struct {
 	// GraphQL directive to generate for the field. Do not include the @ sign,
 	// do include any arguments within parentheses.
 	Directive []string `protobuf:"bytes,6,rep,name=directive,proto3" json:"directive,omitempty"`
+	// Mark a bidirectional streaming method as one where the client only sends
+	// a single message to start the stream. Such methods can be mapped to
+	// subscriptions, with the single request message as the field's input.
+	SingleRequest bool `protobuf:"varint,7,opt,name=single_request,json=singleRequest,proto3" json:"single_request,omitempty"`
+	// Map the method to a Relay connection field. The method must follow the
+	// AIP-158 pagination conventions: the request message has 'page_size' and
+	// 'page_token' fields, and the response message has a 'next_page_token'
+	// field and a single repeated message field of the paginated items.
+	//
+	// For example, a method that returns a repeated Book field generates:
+	//
+	// type MyPackage_BookConnection {
+	//   edges: [MyPackage_BookEdge!]!
+	//   pageInfo: PageInfo!
+	// }
+	//
+	// and the field takes 'first' and 'after' arguments, which are mapped to
+	// the 'page_size' and 'page_token' request fields respectively.
+	Connection bool `protobuf:"varint,8,opt,name=connection,proto3" json:"connection,omitempty"`
+	// Map each top-level field of the request message to its own argument of
+	// the field, instead of a single 'input' argument. For example:
+	//
+	// type MyPackage_Users_Query {
+	//   getUser(id: String): MyPackage_User
+	// }
+	//
+	// Message fields are arguments of their input types. Methods whose request
+	// message has a oneof keep the single 'input' argument, unless oneofs are
+	// flattened by the 'oneof_style' parameter. Can also be enabled for all
+	// methods with the 'flatten_arguments' parameter.
+	FlattenArguments bool `protobuf:"varint,9,opt,name=flatten_arguments,json=flattenArguments,proto3" json:"flatten_arguments,omitempty"`
+	// Dot separated field path in the gRPC method's response message to the
+	// field that the GraphQL field returns, instead of the response message
+	// itself. Has the same semantics as the 'response_field_path' of loaders.
+	//
+	// For example, with 'response_path: "user"' the method:
+	//
+	// rpc GetUser(GetUserRequest) returns (GetUserResponse);
+	//
+	// message GetUserResponse {
+	//   User user = 1;
+	// }
+	//
+	// generates the field 'getUser(...): MyPackage_User' instead of
+	// 'getUser(...): MyPackage_GetUserResponse'. Can't be combined with the
+	// 'connection' option.
+	ResponsePath string `protobuf:"bytes,10,opt,name=response_path,json=responsePath,proto3" json:"response_path,omitempty"`
 	// Deprecated: methods must opt into generation by specifying an 'operation'.
 	Skip                 bool     `protobuf:"varint,5,opt,name=skip,proto3" json:"skip,omitempty"` // Deprecated: Do not use.
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -483,6 +594,34 @@ func (m *MethodOptions) GetDirective() []string {
 	return nil
 }
 
+func (m *MethodOptions) GetSingleRequest() bool {
+	if m != nil {
+		return m.SingleRequest
+	}
+	return false
+}
+
+func (m *MethodOptions) GetConnection() bool {
+	if m != nil {
+		return m.Connection
+	}
+	return false
+}
+
+func (m *MethodOptions) GetFlattenArguments() bool {
+	if m != nil {
+		return m.FlattenArguments
+	}
+	return false
+}
+
+func (m *MethodOptions) GetResponsePath() string {
+	if m != nil {
+		return m.ResponsePath
+	}
+	return ""
+}
+
 // Deprecated: Do not use.
 func (m *MethodOptions) GetSkip() bool {
 	if m != nil {
@@ -574,42 +713,51 @@ func init() {
 func init() { proto.RegisterFile("graphql/options.proto", fileDescriptor_271333f07818dee0) }
 
 var fileDescriptor_271333f07818dee0 = []byte{
-	// 582 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x5d, 0x6b, 0xd4, 0x40,
-	0x14, 0x25, 0xed, 0x76, 0xb7, 0xb9, 0x6b, 0x57, 0x09, 0x6d, 0x4d, 0x75, 0xb5, 0xdb, 0xa0, 0x58,
-	0x90, 0x66, 0x41, 0xdf, 0x82, 0x4f, 0x45, 0x8b, 0x50, 0xd7, 0x4a, 0x14, 0x1f, 0x0a, 0x12, 0xb2,
-	0xd9, 0xbb, 0xd9, 0xa1, 0xc9, 0x4c, 0xcc, 0xc7, 0xe2, 0xfe, 0x01, 0xff, 0x8c, 0x4f, 0x82, 0x3f,
-	0x50, 0x66, 0x92, 0x4c, 0x26, 0x35, 0xea, 0xdb, 0xdc, 0x73, 0xcf, 0x9c, 0xb9, 0x1f, 0x87, 0x81,
-	0x83, 0x30, 0xf5, 0x93, 0xd5, 0xd7, 0x68, 0xca, 0x92, 0x9c, 0x30, 0x9a, 0xd9, 0x49, 0xca, 0x72,
-	0x66, 0x0c, 0x2a, 0xf8, 0xc1, 0x24, 0x64, 0x2c, 0x8c, 0x70, 0x2a, 0xe0, 0x79, 0xb1, 0x9c, 0x2e,
-	0x30, 0x0b, 0x52, 0x92, 0xe4, 0x2c, 0x2d, 0xa9, 0xd6, 0x73, 0x18, 0x5e, 0x90, 0x08, 0xaf, 0xca,
-	0xfb, 0xc6, 0x18, 0x74, 0xea, 0xc7, 0x98, 0x25, 0x7e, 0x80, 0xa6, 0x36, 0xd1, 0x4e, 0x75, 0xb7,
-	0x01, 0xac, 0x27, 0x30, 0x9a, 0x61, 0x96, 0xf9, 0xa1, 0xe4, 0x1b, 0xd0, 0xcb, 0x37, 0x49, 0x4d,
-	0x15, 0x67, 0xeb, 0x97, 0x06, 0x77, 0x2e, 0x08, 0x46, 0x8b, 0x9a, 0xb4, 0x0f, 0x3b, 0x4b, 0x1e,
-	0x57, 0xac, 0x32, 0x90, 0x57, 0xb7, 0x9a, 0xab, 0x1c, 0xcb, 0x6e, 0x48, 0x62, 0x6e, 0x4f, 0xb4,
-	0xd3, 0x5d, 0x57, 0x9c, 0x79, 0x49, 0x0b, 0x92, 0x62, 0x90, 0x93, 0x35, 0x9a, 0xbd, 0xc9, 0x36,
-	0x2f, 0x49, 0x02, 0xc6, 0x33, 0xb8, 0x4b, 0x68, 0x52, 0xe4, 0x5e, 0xc3, 0xe9, 0x0b, 0xce, 0x48,
-	0xc0, 0xaf, 0x25, 0xf1, 0x18, 0x86, 0x4b, 0x96, 0x22, 0x09, 0xa9, 0x77, 0x83, 0x1b, 0x73, 0x47,
-	0xbc, 0x0a, 0x15, 0x74, 0x89, 0x1b, 0xeb, 0x04, 0x86, 0x6f, 0x68, 0x11, 0xff, 0xab, 0xb3, 0x6b,
-	0xb8, 0xc7, 0x29, 0x9f, 0xfd, 0xa8, 0x40, 0xa5, 0xb9, 0x35, 0x8f, 0xeb, 0xe6, 0x44, 0x20, 0x1b,
-	0xd9, 0xfa, 0x5b, 0x23, 0xdb, 0xb7, 0x1a, 0xb1, 0x2e, 0x61, 0xf4, 0x11, 0xd3, 0x35, 0x09, 0xa4,
-	0xf2, 0x53, 0x18, 0xa5, 0xb8, 0xc4, 0x14, 0x69, 0x80, 0x1e, 0x5f, 0x42, 0xf5, 0xc4, 0x9e, 0x44,
-	0xdf, 0xfb, 0x71, 0xe7, 0x53, 0xd6, 0x4f, 0x0d, 0xf6, 0x66, 0x98, 0xaf, 0xd8, 0x7f, 0x76, 0x30,
-	0x06, 0x9d, 0x25, 0x98, 0xfa, 0x9c, 0x53, 0x2d, 0xa2, 0x01, 0x8c, 0x23, 0xd8, 0x8d, 0x98, 0xbf,
-	0xf0, 0x18, 0x45, 0xb1, 0x11, 0xdd, 0x1d, 0xf0, 0xf8, 0x8a, 0xa2, 0xf1, 0x10, 0x74, 0x91, 0x8a,
-	0x7d, 0xba, 0x31, 0x7b, 0x22, 0x27, 0xb8, 0x33, 0x9f, 0x6e, 0xda, 0x8d, 0xf6, 0x6f, 0x6f, 0xec,
-	0xb0, 0xaa, 0x97, 0x6f, 0x60, 0xf7, 0x7c, 0xcb, 0xd4, 0xca, 0x9a, 0x9d, 0xb7, 0xd0, 0x5b, 0x92,
-	0x08, 0x8d, 0xb1, 0x5d, 0x9a, 0xd6, 0xae, 0x4d, 0x6b, 0x2b, 0x06, 0x35, 0x7f, 0x7c, 0xe7, 0xf7,
-	0x86, 0x2f, 0xf6, 0xed, 0xca, 0xe3, 0x6a, 0xd6, 0x15, 0x0a, 0xce, 0x27, 0x18, 0xc4, 0xa5, 0x4d,
-	0x8d, 0xe3, 0x3f, 0xc4, 0xda, 0x06, 0x96, 0x7a, 0xf7, 0xa5, 0x5e, 0x9b, 0xe0, 0xd6, 0x52, 0xce,
-	0xbb, 0x6a, 0x82, 0xc6, 0xa3, 0x8e, 0x02, 0x1b, 0xb7, 0x4b, 0xc5, 0x03, 0xa5, 0xc2, 0x26, 0x5d,
-	0x4d, 0xde, 0x99, 0xc1, 0x20, 0x99, 0x7b, 0x48, 0x8b, 0xb8, 0xa3, 0x61, 0xc5, 0x87, 0x1d, 0x0d,
-	0x2b, 0x59, 0xb7, 0x9f, 0xcc, 0x79, 0xe8, 0x7c, 0x01, 0xe0, 0x5a, 0x5e, 0xe9, 0xbe, 0x93, 0x4e,
-	0x45, 0xd5, 0xb6, 0x52, 0xf6, 0xa8, 0x25, 0xab, 0x52, 0x5c, 0x1d, 0x6b, 0x84, 0x4f, 0x34, 0x2b,
-	0xcd, 0xd9, 0x31, 0xd1, 0xb6, 0x6d, 0x3b, 0x26, 0xda, 0x26, 0xb8, 0xb5, 0x94, 0xf3, 0x01, 0xfa,
-	0xb1, 0x30, 0xa9, 0xf1, 0xb8, 0x63, 0x4d, 0x8a, 0x7b, 0xa5, 0xe6, 0xa1, 0xb2, 0x25, 0x25, 0xef,
-	0x56, 0x3a, 0xe7, 0xaf, 0xae, 0x9d, 0x90, 0xe4, 0xab, 0x62, 0x6e, 0x07, 0x2c, 0x9e, 0xc6, 0x7e,
-	0x9a, 0x13, 0xfa, 0x2d, 0x8b, 0x48, 0x51, 0xfe, 0x80, 0xc1, 0x59, 0x88, 0xf4, 0xac, 0xfe, 0x33,
-	0xe5, 0xa7, 0x58, 0x01, 0xf3, 0xbe, 0x40, 0x5e, 0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xc7, 0xa7,
-	0x56, 0x89, 0x56, 0x05, 0x00, 0x00,
+	// 727 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdb, 0x6e, 0xdb, 0x38,
+	0x10, 0x85, 0x2f, 0xb1, 0xad, 0x71, 0xec, 0xcd, 0x12, 0x49, 0x56, 0xc9, 0xe6, 0xe2, 0x78, 0x11,
+	0x6c, 0x80, 0x20, 0x36, 0xb0, 0xfb, 0x66, 0xec, 0xcb, 0x06, 0x6d, 0xd0, 0x22, 0x75, 0x13, 0xa8,
+	0x41, 0x1f, 0x02, 0x14, 0x02, 0x2d, 0x8f, 0x65, 0x22, 0x12, 0xa9, 0x50, 0x52, 0x50, 0xff, 0x40,
+	0x9f, 0xfb, 0x1f, 0xfd, 0x93, 0x7e, 0x55, 0x41, 0xea, 0xea, 0x54, 0x6d, 0xdf, 0x34, 0x67, 0x86,
+	0x67, 0xc8, 0x33, 0x3c, 0x22, 0xec, 0xb8, 0x92, 0x06, 0xcb, 0x47, 0x6f, 0x2c, 0x82, 0x88, 0x09,
+	0x1e, 0x8e, 0x02, 0x29, 0x22, 0x41, 0xda, 0x29, 0xbc, 0x3f, 0x70, 0x85, 0x70, 0x3d, 0x1c, 0x6b,
+	0x78, 0x16, 0x2f, 0xc6, 0x73, 0x0c, 0x1d, 0xc9, 0x82, 0x48, 0xc8, 0xa4, 0x74, 0x78, 0x0e, 0xdd,
+	0x2b, 0xe6, 0xe1, 0x4d, 0xb2, 0x9e, 0x1c, 0x80, 0xc1, 0xa9, 0x8f, 0x61, 0x40, 0x1d, 0x34, 0x6b,
+	0x83, 0xda, 0x99, 0x61, 0x15, 0xc0, 0xf0, 0x0e, 0xfa, 0x53, 0x0c, 0x43, 0xea, 0xe6, 0xf5, 0x04,
+	0x9a, 0xd1, 0x2a, 0xc8, 0x4a, 0xf5, 0x37, 0xd9, 0x82, 0xc6, 0x03, 0xae, 0xcc, 0xfa, 0xa0, 0x71,
+	0x66, 0x58, 0xea, 0x53, 0xb1, 0x86, 0x4b, 0x2a, 0x91, 0xce, 0x3c, 0x34, 0x1b, 0x83, 0xda, 0x59,
+	0xc7, 0x2a, 0x80, 0xe1, 0xe7, 0x3a, 0x6c, 0x5e, 0x31, 0xf4, 0xe6, 0x19, 0xe9, 0x36, 0x6c, 0x2c,
+	0x54, 0x9c, 0xb2, 0x26, 0x41, 0xde, 0xaa, 0x5e, 0x6a, 0x45, 0xa0, 0x19, 0x3e, 0xb0, 0x20, 0xe5,
+	0xd4, 0xdf, 0xaa, 0xd9, 0x9c, 0x49, 0x74, 0x22, 0xf6, 0x84, 0x66, 0x53, 0x6f, 0xa2, 0x00, 0xc8,
+	0xdf, 0xf0, 0x1b, 0xe3, 0x41, 0x1c, 0xd9, 0x45, 0x4d, 0x4b, 0xd7, 0xf4, 0x35, 0xfc, 0x22, 0x2f,
+	0x3c, 0x86, 0xee, 0x42, 0x48, 0x64, 0x2e, 0xb7, 0xd5, 0x69, 0x36, 0x74, 0x57, 0x48, 0xa1, 0x6b,
+	0x5c, 0x91, 0x43, 0x00, 0xd5, 0xcf, 0xd6, 0xeb, 0xcc, 0x76, 0x7a, 0xaa, 0x07, 0x16, 0xbc, 0x56,
+	0x80, 0x5a, 0xaf, 0xd3, 0x22, 0x8e, 0x54, 0xbe, 0xa3, 0xf3, 0x7a, 0xc5, 0x8d, 0x46, 0xc8, 0x3e,
+	0x74, 0x24, 0x3e, 0xc6, 0x4c, 0xe2, 0xdc, 0x34, 0x74, 0x36, 0x8f, 0x87, 0x27, 0xd0, 0x7d, 0xc9,
+	0x63, 0xff, 0x27, 0x2a, 0x0f, 0xef, 0x61, 0x4b, 0x95, 0xbc, 0xa7, 0x5e, 0x8c, 0x25, 0xe1, 0x9e,
+	0x54, 0x9c, 0x09, 0xa7, 0x83, 0x5c, 0xa4, 0xfa, 0x8f, 0x44, 0x6a, 0x3c, 0x13, 0x69, 0x78, 0x0d,
+	0xfd, 0x77, 0x28, 0x9f, 0x98, 0x93, 0x33, 0x9f, 0x42, 0x5f, 0xe2, 0x02, 0x25, 0x72, 0x07, 0x6d,
+	0x75, 0x21, 0xd2, 0x16, 0xbd, 0x1c, 0x7d, 0x4b, 0xfd, 0xca, 0x56, 0xc3, 0xaf, 0x75, 0xe8, 0x4d,
+	0x31, 0x5a, 0x8a, 0x5f, 0xcc, 0xf7, 0x00, 0x0c, 0x11, 0xa0, 0xa4, 0xaa, 0x26, 0x1d, 0x72, 0x01,
+	0x90, 0x3d, 0xe8, 0x78, 0x82, 0xce, 0x6d, 0xc1, 0x93, 0x1b, 0x64, 0x58, 0x6d, 0x15, 0xdf, 0x70,
+	0x24, 0x7f, 0x82, 0xa1, 0x53, 0x3e, 0xe5, 0x2b, 0xb3, 0xa9, 0x73, 0xba, 0x76, 0x4a, 0xf9, 0x6a,
+	0xfd, 0xa0, 0xad, 0xe7, 0xb7, 0xe1, 0x14, 0xfa, 0x21, 0xe3, 0xae, 0x87, 0xb6, 0x92, 0x1e, 0xc3,
+	0x6c, 0x8e, 0xbd, 0x04, 0xb5, 0x12, 0x90, 0x1c, 0x01, 0x38, 0x82, 0x73, 0xb5, 0x48, 0xf0, 0x6c,
+	0x94, 0x05, 0x42, 0xce, 0xe1, 0xf7, 0x85, 0x47, 0xa3, 0x08, 0xb9, 0x4d, 0xa5, 0x1b, 0xfb, 0xc8,
+	0xa3, 0x30, 0x9d, 0xe9, 0x56, 0x9a, 0xf8, 0x3f, 0xc3, 0xc9, 0x5f, 0xd0, 0x93, 0x18, 0x06, 0x82,
+	0x87, 0x68, 0x07, 0x34, 0x5a, 0x9a, 0xa0, 0xb7, 0xbc, 0x99, 0x81, 0xb7, 0x34, 0x5a, 0x92, 0xdd,
+	0x54, 0x48, 0x75, 0xed, 0x3a, 0x97, 0x75, 0xb3, 0x96, 0x88, 0x39, 0x79, 0x05, 0xcd, 0x05, 0xf3,
+	0x90, 0x1c, 0x8c, 0x12, 0x67, 0x8f, 0x32, 0x67, 0x8f, 0x4a, 0x2e, 0x36, 0xbf, 0x7c, 0x52, 0xeb,
+	0xba, 0xff, 0x6c, 0x8f, 0xd2, 0x1f, 0x41, 0x39, 0x6b, 0x69, 0x86, 0xc9, 0x1d, 0xb4, 0xfd, 0xc4,
+	0xcb, 0xe4, 0xf8, 0x3b, 0xb2, 0x75, 0x97, 0xe7, 0x7c, 0x7f, 0xe4, 0x7c, 0xeb, 0x05, 0x56, 0x46,
+	0x35, 0x79, 0x93, 0x8e, 0x96, 0x1c, 0x56, 0x6c, 0xb0, 0xb0, 0x78, 0xce, 0xb8, 0x53, 0xda, 0x61,
+	0x91, 0x4e, 0xaf, 0xc4, 0x64, 0x0a, 0xed, 0x60, 0x66, 0x23, 0x8f, 0xfd, 0x8a, 0x03, 0x97, 0x0c,
+	0x52, 0x71, 0xe0, 0x52, 0xd6, 0x6a, 0x05, 0x33, 0x15, 0x4e, 0x3e, 0x00, 0x28, 0x2e, 0x3b, 0xb1,
+	0xc5, 0x49, 0x25, 0x63, 0xd9, 0x4f, 0x39, 0xed, 0xde, 0x1a, 0x6d, 0xb9, 0xc4, 0x32, 0x30, 0x43,
+	0x94, 0xa2, 0x61, 0xe2, 0x9a, 0x0a, 0x45, 0xd7, 0xfd, 0x54, 0xa1, 0xe8, 0x7a, 0x81, 0x95, 0x51,
+	0x4d, 0x6e, 0xa1, 0xe5, 0x6b, 0xf7, 0x90, 0xa3, 0x8a, 0x31, 0x95, 0x6c, 0x95, 0x73, 0xee, 0x96,
+	0xa6, 0x54, 0xca, 0x5b, 0x29, 0xcf, 0xe5, 0x7f, 0xf7, 0x13, 0x97, 0x45, 0xcb, 0x78, 0x36, 0x72,
+	0x84, 0x3f, 0xf6, 0xa9, 0x8c, 0x18, 0xff, 0x18, 0x7a, 0x2c, 0x4e, 0x9e, 0x09, 0xe7, 0xc2, 0x45,
+	0x7e, 0x91, 0x3d, 0x2c, 0xf9, 0xcb, 0x91, 0x02, 0xb3, 0x96, 0x46, 0xfe, 0xfd, 0x36, 0x00, 0x9c,
+	0x46, 0x44, 0x39, 0x7b, 0x06, 0x00, 0x00,
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+var builtinScalars = map[string]bool{
+	graphql.ScalarInt.TypeName():     true,
+	graphql.ScalarFloat.TypeName():   true,
+	graphql.ScalarString.TypeName():  true,
+	graphql.ScalarBoolean.TypeName(): true,
+	graphql.ScalarID.TypeName():      true,
+}
+
+// generateScalars generates a file with a definition of each custom scalar
+// referenced by the types generated for the files to generate. A type name is
+// considered to be a custom scalar if it is neither a built-in scalar nor the
+// name of a type mapped from a protobuf descriptor, e.g. the names given by
+// the 'timestamp' parameter or the 'type' field option.
+func (g *Generator) generateScalars(params *parameters.Parameters) {
+	defined := g.definedTypeNames()
+
+	referenced := make(map[string]bool)
+	for _, fileName := range g.req.GetFileToGenerate() {
+		for _, gqlType := range g.fileTypes(fileName) {
+			for _, typeName := range referencedTypeNames(gqlType) {
+				typeName = strings.Trim(typeName, "[]!")
+				if !builtinScalars[typeName] && !defined[typeName] {
+					referenced[typeName] = true
+				}
+			}
+		}
+	}
+
+	var names []string
+	for name := range referenced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var scalars []graphql.Type
+	for _, name := range names {
+		scalars = append(scalars, &graphql.Scalar{
+			Name:           name,
+			SpecifiedByURL: params.SpecifiedByURLs[name],
+		})
+	}
+
+	g.writeFile(params.ScalarsFileName, scalars, params)
+}
+
+// definedTypeNames returns the names of all GraphQL types that are mapped from
+// protobuf descriptors, including those in files that are not generated.
+func (g *Generator) definedTypeNames() map[string]bool {
+	defined := make(map[string]bool)
+	for _, gqlType := range g.mappedTypes() {
+		defined[gqlType.TypeName()] = true
+	}
+	return defined
+}
+
+// referencedTypeNames returns the type names of the fields and arguments of
+// a GraphQL type.
+func referencedTypeNames(gqlType graphql.Type) []string {
+	var typeNames []string
+	for _, field := range typeFields(gqlType) {
+		typeNames = append(typeNames, field.TypeName)
+		for _, argument := range field.Arguments {
+			typeNames = append(typeNames, argument.TypeName)
+		}
+	}
+	return typeNames
+}
+
+// typeFields returns the fields of a GraphQL type, or nil if the type has no
+// fields.
+func typeFields(gqlType graphql.Type) []*graphql.Field {
+	switch gqlType := gqlType.(type) {
+	case *graphql.Object:
+		return gqlType.Fields
+	case *graphql.ExtendObject:
+		return gqlType.Fields
+	case *graphql.Input:
+		return gqlType.Fields
+	case *graphql.Interface:
+		return gqlType.Fields
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/martinxsliu/protoc-gen-graphql/diagnostics"
+	"github.com/martinxsliu/protoc-gen-graphql/graphql"
+	"github.com/martinxsliu/protoc-gen-graphql/mapper"
+	"github.com/martinxsliu/protoc-gen-graphql/parameters"
+)
+
+// validateSchema validates the types of the output files, and records an
+// error for each problem at the protobuf definition that the offending type,
+// field or enum value is mapped from.
+//
+// Types in files that are not generated can be referenced, as they are output
+// when their own files are generated. Other referenced type names are assumed
+// to be custom scalars, like the scalars output to the 'scalars_file', with a
+// warning unless they are known from the parameters. Names of types that are
+// mapped from protobuf descriptors but not generated, e.g. inputs that are left
+// out by the 'input_mode' parameter, are not assumed to be scalars.
+func (g *Generator) validateSchema(params *parameters.Parameters) {
+	groups := g.outputGroups(params)
+
+	definitions := make(map[string]graphql.Type)
+	for _, gqlType := range g.mappedTypes() {
+		definitions[gqlType.TypeName()] = gqlType
+	}
+	fileNames := make(map[graphql.Type]string)
+	var types []graphql.Type
+	for _, group := range groups {
+		for _, gqlType := range group.types {
+			if _, ok := gqlType.(*graphql.ExtendObject); !ok && gqlType.TypeName() != "" {
+				definitions[gqlType.TypeName()] = gqlType
+			}
+			fileNames[gqlType] = group.fileName
+			types = append(types, gqlType)
+		}
+	}
+
+	// Names of types that are mapped from descriptors, but not generated.
+	mappedNames := make(map[string]bool)
+	for _, names := range []map[string]string{g.mapper.ObjectNames, g.mapper.InputNames} {
+		for _, name := range names {
+			mappedNames[name] = true
+		}
+	}
+
+	l := g.newLocator()
+	scalars := customScalarNames(params)
+	assumeScalar := func(err *graphql.ValidationError, typeName string) {
+		typeName = strings.Trim(typeName, "[]!")
+		if _, ok := definitions[typeName]; ok || typeName == "" || builtinScalars[typeName] || mappedNames[typeName] {
+			return
+		}
+		if !scalars[typeName] {
+			g.diagnostics.Warnf(l.location(err, fileNames[err.Type]),
+				"type %s is not declared and is assumed to be a custom scalar, declare it with the custom_scalar parameter", typeName)
+		}
+		definitions[typeName] = &graphql.Scalar{Name: typeName}
+	}
+	for _, gqlType := range types {
+		for _, field := range typeFields(gqlType) {
+			assumeScalar(&graphql.ValidationError{Type: gqlType, Field: field}, field.TypeName)
+			for _, argument := range field.Arguments {
+				assumeScalar(&graphql.ValidationError{Type: gqlType, Field: field, Argument: argument}, argument.TypeName)
+			}
+		}
+	}
+
+	for _, err := range graphql.Validate(types, definitions) {
+		g.diagnostics.Errorf(l.location(err, fileNames[err.Type]), "%s", err.Message)
+	}
+}
+
+// customScalarNames returns the names of the custom scalars that the
+// parameters map protobuf types to or declare.
+func customScalarNames(params *parameters.Parameters) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range []string{params.TimestampTypeName, params.DurationTypeName, params.StructTypeName, params.MapScalarName} {
+		if name != "" {
+			names[name] = true
+		}
+	}
+	for _, name := range params.ScalarTypeNames {
+		names[name] = true
+	}
+	for name := range params.SpecifiedByURLs {
+		names[name] = true
+	}
+	for _, name := range params.CustomScalars {
+		names[name] = true
+	}
+	return names
+}
+
+// mappedTypes returns all GraphQL types that are mapped from protobuf
+// descriptors, including those in files that are not generated.
+func (g *Generator) mappedTypes() []graphql.Type {
+	var gqlTypes []graphql.Type
+	for _, m := range g.mapper.MessageMappers {
+		if m.Object != nil {
+			gqlTypes = append(gqlTypes, m.Object)
+		}
+		if m.Input != nil {
+			gqlTypes = append(gqlTypes, m.Input)
+		}
+		for _, oneof := range m.Oneofs {
+			gqlTypes = append(gqlTypes, oneof.Type())
+			for _, object := range oneof.Objects {
+				gqlTypes = append(gqlTypes, object)
+			}
+			if oneof.Input != nil {
+				gqlTypes = append(gqlTypes, oneof.Input)
+			}
+		}
+	}
+	for _, m := range g.mapper.EnumMappers {
+		gqlTypes = append(gqlTypes, m.Enum)
+	}
+	for _, m := range g.mapper.ServiceMappers {
+		for _, methods := range []*mapper.MethodsMapper{m.Queries, m.Mutations, m.Subscriptions} {
+			if methods != nil {
+				gqlTypes = append(gqlTypes, methods.Object)
+			}
+		}
+	}
+	for _, connection := range g.mapper.Connections {
+		gqlTypes = append(gqlTypes, connection.Connection, connection.Edge)
+	}
+	if g.mapper.PageInfo != nil {
+		gqlTypes = append(gqlTypes, g.mapper.PageInfo)
+	}
+	return gqlTypes
+}
+
+// locator finds the protobuf definitions that GraphQL types, fields and enum
+// values are mapped from.
+type locator struct {
+	mapper *mapper.Mapper
+	types  map[graphql.Type]diagnostics.Location
+	fields map[*graphql.Field]diagnostics.Location
+}
+
+func (g *Generator) newLocator() *locator {
+	l := &locator{
+		mapper: g.mapper,
+		types:  make(map[graphql.Type]diagnostics.Location),
+		fields: make(map[*graphql.Field]diagnostics.Location),
+	}
+
+	for _, m := range g.mapper.MessageMappers {
+		if m.Object != nil {
+			l.types[m.Object] = m.Descriptor.Location
+		}
+		if m.Input != nil {
+			l.types[m.Input] = m.Descriptor.Location
+		}
+		for _, oneof := range m.Oneofs {
+			l.types[oneof.Type()] = oneof.Descriptor.Location
+			for _, object := range oneof.Objects {
+				l.types[object] = oneof.Descriptor.Location
+			}
+			if oneof.Input != nil {
+				l.types[oneof.Input] = oneof.Descriptor.Location
+			}
+		}
+	}
+	for _, m := range g.mapper.EnumMappers {
+		l.types[m.Enum] = m.Descriptor.Location
+	}
+	for _, m := range g.mapper.ServiceMappers {
+		for _, methods := range []*mapper.MethodsMapper{m.Queries, m.Mutations, m.Subscriptions} {
+			if methods == nil {
+				continue
+			}
+			l.types[methods.Object] = m.Descriptor.Location
+			// The fields of a methods mapper's object correspond to its methods.
+			for i, field := range methods.Object.Fields {
+				l.fields[field] = methods.Methods[i].Location
+			}
+		}
+	}
+	for method, connection := range g.mapper.Connections {
+		l.types[connection.Connection] = method.Location
+		l.types[connection.Edge] = method.Location
+	}
+	return l
+}
+
+// location returns the location of the most specific definition that a
+// validation error relates to. Types that are not mapped from a definition,
+// such as the federation types, are located at the file they are output to.
+func (l *locator) location(err *graphql.ValidationError, fileName string) diagnostics.Location {
+	if d, ok := l.mapper.EnumValueDescriptors[err.EnumValue]; ok {
+		return d.Location
+	}
+	if d, ok := l.mapper.FieldDescriptors[err.Field]; ok {
+		return d.Location
+	}
+	if d, ok := l.mapper.ForeignKeyFields[err.Field]; ok {
+		return d.Location
+	}
+	if location, ok := l.fields[err.Field]; ok {
+		return location
+	}
+	if location, ok := l.types[err.Type]; ok {
+		return location
+	}
+	return diagnostics.Location{File: fileName}
+}